@@ -0,0 +1,141 @@
+package rid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"math/bits"
+	"unicode/utf8"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Random strings over arbitrary alphabets
+///////////////////////////////////////////////////////////////////////////
+
+// NewStringFrom returns n characters drawn uniformly from alphabet, which may contain any distinct UTF-8 characters.
+// Randomness comes from the same source as NewRIDn.
+func NewStringFrom(alphabet string, n int) (string, error) {
+	runes, err := alphabetRunes(alphabet)
+	if err != nil {
+		return "", err
+	}
+	var b []byte
+	if len(runes) == len(alphabet) {
+		b, err = appendAlphabet(make([]byte, 0, n), packageReader(), []byte(alphabet), n)
+	} else {
+		b, err = appendRunes(make([]byte, 0, n*utf8.UTFMax), packageReader(), runes, n)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// MustStringFrom is like NewStringFrom but panics on invalid alphabet
+func MustStringFrom(alphabet string, n int) string {
+	s, err := NewStringFrom(alphabet, n)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ValidFrom checks that id consists of n characters from alphabet
+func ValidFrom(alphabet string, id string, n int) bool {
+	if utf8.RuneCountInString(id) != n {
+		return false
+	}
+	var set = make(map[rune]bool, len(alphabet))
+	for _, r := range alphabet {
+		set[r] = true
+	}
+	for _, r := range id {
+		if !set[r] {
+			return false
+		}
+	}
+	return true
+}
+
+// distinct characters of alphabet, at least 2
+func alphabetRunes(alphabet string) ([]rune, error) {
+	if !utf8.ValidString(alphabet) {
+		return nil, fmt.Errorf("rid: alphabet is not valid UTF-8")
+	}
+	var runes = []rune(alphabet)
+	if len(runes) < 2 {
+		return nil, fmt.Errorf("rid: alphabet must have at least 2 characters")
+	}
+	var seen = make(map[rune]bool, len(runes))
+	for _, r := range runes {
+		if seen[r] {
+			return nil, fmt.Errorf("rid: duplicate character %q in alphabet", r)
+		}
+		seen[r] = true
+	}
+	return runes, nil
+}
+
+// As appendAlphabet, but for alphabets of multi-byte characters or more than 256 characters.
+// Every character costs 4 random bytes before rejection.
+func appendRunes(dst []byte, r io.Reader, alphabet []rune, n int) ([]byte, error) {
+	var mask = uint32(1)<<bits.Len32(uint32(len(alphabet)-1)) - 1
+	var buf = readBufPool.Get().(*[64]byte)
+	defer readBufPool.Put(buf)
+	for n > 0 {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return dst, err
+		}
+		for i := 0; i < len(buf) && n > 0; i += 4 {
+			var x = binary.LittleEndian.Uint32(buf[i:]) & mask
+			if int(x) < len(alphabet) {
+				dst = utf8.AppendRune(dst, alphabet[x])
+				n--
+			}
+		}
+	}
+	return dst, nil
+}
+
+// the source used by package-level functions, see SetDefault and SetRand
+func packageReader() io.Reader {
+	if g := Default(); g != nil {
+		return g.reader()
+	}
+	if r := getCustomRand(); r != nil {
+		return r
+	}
+	if FIPSMode() {
+		return rand.Reader
+	}
+	return internalRand
+}
+
+// uniform random int in [0, n) for n up to 1<<32, by rejection sampling on masked 32-bit values
+func uniformInt(r io.Reader, n int) (int, error) {
+	if n <= 0 {
+		panic(fmt.Sprintf("rid: empty range %d for uniformInt", n))
+	}
+	var mask = uint32(1)<<bits.Len32(uint32(n-1)) - 1
+	var buf [4]byte
+	for {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return 0, err
+		}
+		if x := binary.LittleEndian.Uint32(buf[:]) & mask; int(x) < n {
+			return int(x), nil
+		}
+	}
+}
+
+// as uniformInt from the same source as NewRIDn
+func randomInt(n int) int {
+	x, err := uniformInt(packageReader(), n)
+	if err != nil {
+		//severe error - looks like a failure of random number generator
+		log.Fatal(err)
+	}
+	return x
+}
@@ -0,0 +1,43 @@
+package rid
+
+import (
+	"testing"
+)
+
+func Test_stringFrom(t *testing.T) {
+	var base32 = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+	s, err := NewStringFrom(base32, 26)
+	if err != nil || !ValidFrom(base32, s, 26) {
+		t.Fatalf("unexpected base32 string: %s, %v\n", s, err)
+	}
+	if ValidFrom(base32, s+"1", 27) || ValidFrom(base32, s, 25) {
+		t.Fatalf("validator too lenient")
+	}
+	s, err = NewStringFrom("αβγδ", 10)
+	if err != nil || len(s) != 20 || !ValidFrom("αβγδ", s, 10) {
+		t.Fatalf("unexpected greek string: %s, %v\n", s, err)
+	}
+	for _, bad := range []string{"", "a", "abca", "\xff\xfe"} {
+		if _, err = NewStringFrom(bad, 5); err == nil {
+			t.Fatalf("alphabet %q should be rejected\n", bad)
+		}
+	}
+}
+
+func Test_runesUniform(t *testing.T) {
+	var alphabet = []rune("abcde")
+	b, _ := appendRunes(nil, DeterministicGenerator(1).reader(), alphabet, 50000)
+	var freq = make(map[rune]int)
+	for _, r := range string(b) {
+		freq[r]++
+	}
+	var chi2 float64
+	for _, r := range alphabet {
+		var d = float64(freq[r] - 10000)
+		chi2 += d * d / 10000
+	}
+	// critical value for 4 degrees of freedom, p = 0.001
+	if chi2 > 18.47 {
+		t.Fatalf("rune distribution not uniform, chi2 = %.1f\n", chi2)
+	}
+}
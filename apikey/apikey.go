@@ -0,0 +1,77 @@
+// Package apikey issues API keys of the form prefix_publicID_secret.
+// The public ID locates the key record, only the SHA-256 of the secret is stored.
+package apikey
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/seckiss/rid"
+)
+
+const (
+	// 71.5 bits, unique enough for a database index
+	PublicIDLength = 12
+	// 190.5 bits, a random secret needs no slow password hash
+	SecretLength = 32
+)
+
+var ErrInvalidKey = errors.New("apikey: invalid API key")
+
+var (
+	publicIDs = rid.NewCrypto(rid.WithLength(PublicIDLength))
+	secrets   = rid.NewCrypto(rid.WithLength(SecretLength))
+)
+
+type Key struct {
+	// shown to the user once, never stored
+	Key      string
+	PublicID string
+	// hex SHA-256 of the secret, stored with PublicID
+	Hash string
+}
+
+// New reads from crypto/rand. The prefix, e.g. "acme_live", tells secret scanners and humans what the key is for.
+// It panics unless rid.ValidPrefix(prefix).
+func New(prefix string) Key {
+	if !rid.ValidPrefix(prefix) {
+		panic(fmt.Sprintf("apikey: invalid prefix %q", prefix))
+	}
+	var public, secret = publicIDs.NewID(), secrets.NewID()
+	return Key{Key: prefix + "_" + public + "_" + secret, PublicID: public, Hash: hash(secret)}
+}
+
+// Parse splits key into its parts, the prefix may contain underscores
+func Parse(key string) (prefix, publicID, secret string, err error) {
+	var i = strings.LastIndexByte(key, '_')
+	if i < 0 {
+		return "", "", "", ErrInvalidKey
+	}
+	var j = strings.LastIndexByte(key[:i], '_')
+	if j < 1 {
+		return "", "", "", ErrInvalidKey
+	}
+	prefix, publicID, secret = key[:j], key[j+1:i], key[i+1:]
+	if len(publicID) != PublicIDLength || len(secret) != SecretLength || !rid.ValidFrom(rid.Base62Alphabet, publicID+secret, PublicIDLength+SecretLength) {
+		return "", "", "", ErrInvalidKey
+	}
+	return prefix, publicID, secret, nil
+}
+
+// Verify checks a presented key against the Hash stored for its public ID in constant time
+func Verify(key, storedHash string) bool {
+	_, _, secret, err := Parse(key)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hash(secret)), []byte(storedHash)) == 1
+}
+
+func hash(secret string) string {
+	var sum = sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
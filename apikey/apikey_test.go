@@ -0,0 +1,50 @@
+package apikey
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/seckiss/rid"
+)
+
+func Test_apikey(t *testing.T) {
+	var k = New("acme_live")
+	if !strings.HasPrefix(k.Key, "acme_live_"+k.PublicID+"_") || len(k.Key) != 10+12+1+32 || len(k.Hash) != 64 {
+		t.Fatalf("unexpected API key %v\n", k)
+	}
+	if strings.Contains(k.Hash, k.Key[23:]) {
+		t.Fatalf("secret stored in the clear")
+	}
+	prefix, public, secret, err := Parse(k.Key)
+	if err != nil || prefix != "acme_live" || public != k.PublicID || secret != k.Key[23:] {
+		t.Fatalf("Parse(%s) = %s, %s, %s, %v\n", k.Key, prefix, public, secret, err)
+	}
+	if !Verify(k.Key, k.Hash) {
+		t.Fatalf("API key not verified")
+	}
+	if Verify(New("acme_live").Key, k.Hash) || Verify(k.Key[:len(k.Key)-1], k.Hash) {
+		t.Fatalf("wrong API key verified")
+	}
+	if _, _, _, err = Parse("_" + k.Key[10:]); err != ErrInvalidKey {
+		t.Fatalf("key without prefix accepted")
+	}
+}
+
+func Test_newParses(t *testing.T) {
+	for _, prefix := range []string{"a", "acme_live", "x9_y_z", "", "_live", "acme_", "Acme", "acme-live"} {
+		func() {
+			defer func() {
+				if recover() != nil && rid.ValidPrefix(prefix) {
+					t.Fatalf("New(%q) panicked\n", prefix)
+				}
+			}()
+			var k = New(prefix)
+			if !rid.ValidPrefix(prefix) {
+				t.Fatalf("New(%q) accepted an invalid prefix\n", prefix)
+			}
+			if p, _, _, err := Parse(k.Key); err != nil || p != prefix || !Verify(k.Key, k.Hash) {
+				t.Fatalf("key %s of New does not parse: %s, %v\n", k.Key, p, err)
+			}
+		}()
+	}
+}
@@ -0,0 +1,68 @@
+// Package awskms signs and verifies rid.NewRIDSignedWith IDs with AWS KMS HMAC_256 keys,
+// so the MAC keys never leave KMS. Importing it pulls in the AWS SDK, the rid package doesn't.
+package awskms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/seckiss/rid"
+)
+
+// Client is the part of *kms.Client used here
+type Client interface {
+	GenerateMac(ctx context.Context, params *kms.GenerateMacInput, optFns ...func(*kms.Options)) (*kms.GenerateMacOutput, error)
+	VerifyMac(ctx context.Context, params *kms.VerifyMacInput, optFns ...func(*kms.Options)) (*kms.VerifyMacOutput, error)
+}
+
+// Signer signs with the key Current and verifies with any key of Keys.
+// Keys maps the key IDs embedded in signed IDs, 1 to 8 base62 characters, to key IDs, ARNs or aliases of KMS,
+// so a key can be rotated by adding it under a new ID and making it Current.
+type Signer struct {
+	Client  Client
+	Keys    map[string]string
+	Current string
+}
+
+var (
+	_ rid.Signer   = (*Signer)(nil)
+	_ rid.Verifier = (*Signer)(nil)
+)
+
+func (s *Signer) Sign(ctx context.Context, message []byte) (string, []byte, error) {
+	var key, ok = s.Keys[s.Current]
+	if !ok {
+		return "", nil, fmt.Errorf("awskms: no key for current key ID %q", s.Current)
+	}
+	out, err := s.Client.GenerateMac(ctx, &kms.GenerateMacInput{
+		KeyId:        &key,
+		Message:      message,
+		MacAlgorithm: types.MacAlgorithmSpecHmacSha256,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return s.Current, out.Mac, nil
+}
+
+// Verify returns rid.ErrBadSignature for an unknown key ID or a wrong MAC, other errors come from KMS
+func (s *Signer) Verify(ctx context.Context, keyID string, message, mac []byte) error {
+	var key, ok = s.Keys[keyID]
+	if !ok {
+		return rid.ErrBadSignature
+	}
+	out, err := s.Client.VerifyMac(ctx, &kms.VerifyMacInput{
+		KeyId:        &key,
+		Message:      message,
+		Mac:          mac,
+		MacAlgorithm: types.MacAlgorithmSpecHmacSha256,
+	})
+	var invalid *types.KMSInvalidMacException
+	if errors.As(err, &invalid) || err == nil && !out.MacValid {
+		return rid.ErrBadSignature
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+package awskms
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/seckiss/rid"
+)
+
+// HMAC-SHA256 keys by ARN, invalid MACs fail like KMS does
+type fakeKMS map[string][]byte
+
+func (f fakeKMS) mac(key string, message []byte) []byte {
+	var h = hmac.New(sha256.New, f[key])
+	h.Write(message)
+	return h.Sum(nil)
+}
+
+func (f fakeKMS) GenerateMac(_ context.Context, in *kms.GenerateMacInput, _ ...func(*kms.Options)) (*kms.GenerateMacOutput, error) {
+	return &kms.GenerateMacOutput{Mac: f.mac(*in.KeyId, in.Message)}, nil
+}
+
+func (f fakeKMS) VerifyMac(_ context.Context, in *kms.VerifyMacInput, _ ...func(*kms.Options)) (*kms.VerifyMacOutput, error) {
+	if !hmac.Equal(f.mac(*in.KeyId, in.Message), in.Mac) {
+		return nil, &types.KMSInvalidMacException{}
+	}
+	return &kms.VerifyMacOutput{MacValid: true}, nil
+}
+
+func Test_signer(t *testing.T) {
+	var ctx = context.Background()
+	var client = fakeKMS{"arn:k1": []byte("one"), "arn:k2": []byte("two")}
+	var s = &Signer{Client: client, Keys: map[string]string{"k1": "arn:k1", "k2": "arn:k2"}, Current: "k1"}
+	id, err := rid.NewRIDSignedWith(ctx, s)
+	if err != nil || id[20:22] != "k1" {
+		t.Fatalf("NewRIDSignedWith = %s, %v\n", id, err)
+	}
+	if err = rid.VerifyRIDSignedWith(ctx, id, s); err != nil {
+		t.Fatalf("VerifyRIDSignedWith(%s) = %v\n", id, err)
+	}
+	// rotated: new IDs use k2, old ones still verify
+	s.Current = "k2"
+	if err = rid.VerifyRIDSignedWith(ctx, id, s); err != nil {
+		t.Fatalf("ID of previous key rejected: %v\n", err)
+	}
+	if err = rid.VerifyRIDSignedWith(ctx, id[:20]+"k2"+id[22:], s); err != rid.ErrBadSignature {
+		t.Fatalf("ID with swapped key ID gave %v\n", err)
+	}
+	s.Current = "k3"
+	if _, err = rid.NewRIDSignedWith(ctx, s); err == nil {
+		t.Fatalf("signed without a current key\n")
+	}
+}
@@ -0,0 +1,117 @@
+package rid
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// base62 numbers, digits in B62ascii order (A=0 ... 9=61)
+///////////////////////////////////////////////////////////////////////////
+
+var big62 = big.NewInt(62)
+
+// b62index maps a character to its digit value, -1 outside base62
+var b62index = func() (index [256]int8) {
+	for i := range index {
+		index[i] = -1
+	}
+	for i, c := range B62ascii {
+		index[c] = int8(i)
+	}
+	return
+}()
+
+// value of s read as big-endian base62 number
+func b62ToInt(s string) (*big.Int, bool) {
+	var x = new(big.Int)
+	for i := 0; i < len(s); i++ {
+		var d = b62index[s[i]]
+		if d < 0 {
+			return nil, false
+		}
+		x.Mul(x, big62)
+		x.Add(x, big.NewInt(int64(d)))
+	}
+	return x, true
+}
+
+// x as base62 number left padded with zero digits to n characters, false if it does not fit
+func intToB62(x *big.Int, n int) (string, bool) {
+	return intToDigits(x, Base62Alphabet, n)
+}
+
+// x as number in alphabet left padded with zero digits to n characters, false if it does not fit
+func intToDigits(x *big.Int, alphabet string, n int) (string, bool) {
+	var b = make([]byte, n)
+	var base = big.NewInt(int64(len(alphabet)))
+	var q, r = new(big.Int).Set(x), new(big.Int)
+	for i := n - 1; i >= 0; i-- {
+		q.DivMod(q, base, r)
+		b[i] = alphabet[r.Int64()]
+	}
+	return string(b), q.Sign() == 0
+}
+
+// value of s read as big-endian number in alphabet
+func digitsToInt(s string, alphabet string) (*big.Int, bool) {
+	var base = big.NewInt(int64(len(alphabet)))
+	var x = new(big.Int)
+	for i := 0; i < len(s); i++ {
+		var d = strings.IndexByte(alphabet, s[i])
+		if d < 0 {
+			return nil, false
+		}
+		x.Mul(x, base)
+		x.Add(x, big.NewInt(int64(d)))
+	}
+	return x, true
+}
+
+// EncodeBase62 renders b as a big-endian number in the RID alphabet.
+// As in base58 encoding, every leading zero byte becomes a leading 'A', so that the encoding round-trips.
+func EncodeBase62(b []byte) string {
+	var zeros = 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+	var x = new(big.Int).SetBytes(b[zeros:])
+	// 8 bits per byte, at least 5.95 bits per character
+	var out = make([]byte, 0, zeros+len(b[zeros:])*4/3+1)
+	var r = new(big.Int)
+	for x.Sign() > 0 {
+		x.DivMod(x, big62, r)
+		out = append(out, B62ascii[r.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		out = append(out, B62ascii[0])
+	}
+	slices.Reverse(out)
+	return string(out)
+}
+
+var ErrInvalidBase62 = errors.New("rid: invalid base62")
+
+// DecodeBase62 is the inverse of EncodeBase62, leading 'A's become zero bytes
+func DecodeBase62(s string) ([]byte, error) {
+	var zeros = 0
+	for zeros < len(s) && s[zeros] == B62ascii[0] {
+		zeros++
+	}
+	x, err := DecodeBase62Int(s[zeros:])
+	if err != nil {
+		return nil, err
+	}
+	return append(make([]byte, zeros), x.Bytes()...), nil
+}
+
+// DecodeBase62Int returns the value of s as big-endian base62 number, e.g. of a RID to store it as binary
+func DecodeBase62Int(s string) (*big.Int, error) {
+	x, ok := b62ToInt(s)
+	if !ok {
+		return nil, ErrInvalidBase62
+	}
+	return x, nil
+}
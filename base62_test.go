@@ -0,0 +1,42 @@
+package rid
+
+import (
+	"testing"
+)
+
+func Test_encodeBase62(t *testing.T) {
+	for _, c := range []struct {
+		in  []byte
+		out string
+	}{
+		{nil, ""},
+		{[]byte{0}, "A"},
+		{[]byte{61}, "9"},
+		{[]byte{62}, "BA"},
+		{[]byte{0, 0, 1, 0}, "AAEI"},
+	} {
+		if s := EncodeBase62(c.in); s != c.out {
+			t.Fatalf("EncodeBase62(%v) = %s, want %s\n", c.in, s, c.out)
+		}
+	}
+}
+
+func Test_decodeBase62(t *testing.T) {
+	for _, in := range [][]byte{{}, {0}, {0, 0, 1, 0}, {255, 254, 0}, randomBytes(16)} {
+		b, err := DecodeBase62(EncodeBase62(in))
+		if err != nil || string(b) != string(in) {
+			t.Fatalf("round trip of %v gave %v, %v\n", in, b, err)
+		}
+	}
+	var id = NewRID20()
+	x, err := DecodeBase62Int(id)
+	if err != nil || x.BitLen() > 120 {
+		t.Fatalf("unexpected value of %s: %v, %v\n", id, x, err)
+	}
+	if back, _ := intToB62(x, 20); back != id {
+		t.Fatalf("RID value round trip failed: %s, %s\n", id, back)
+	}
+	if _, err = DecodeBase62("ab-c"); err != ErrInvalidBase62 {
+		t.Fatalf("expected ErrInvalidBase62, got %v\n", err)
+	}
+}
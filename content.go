@@ -0,0 +1,62 @@
+package rid
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Content IDs, a truncated base62 digest of the content, for deduplication
+///////////////////////////////////////////////////////////////////////////
+
+type contentConfig struct {
+	hash   func() hash.Hash
+	length int
+}
+
+type ContentOption func(*contentConfig)
+
+// default hash is SHA-256
+func WithContentHash(h func() hash.Hash) ContentOption {
+	return func(c *contentConfig) {
+		c.hash = h
+	}
+}
+
+// default length is 22 chars, 131 bits, at most the bits of the digest are useful
+func WithContentLength(n int) ContentOption {
+	if n < 1 {
+		panic(fmt.Sprintf("rid: invalid length %d", n))
+	}
+	return func(c *contentConfig) {
+		c.length = n
+	}
+}
+
+// NewContentID reads r to the end and returns the digest modulo 62^n in n chars of the RID alphabet.
+// Equal content gives equal IDs for the same options.
+func NewContentID(r io.Reader, opts ...ContentOption) (string, error) {
+	var c = contentConfig{hash: sha256.New, length: 22}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	var h = c.hash()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	s, _ := intToDigits(new(big.Int).SetBytes(h.Sum(nil)), Base62Alphabet, c.length)
+	return s, nil
+}
+
+// VerifyContentID checks that r hashes to id with the same options as NewContentID
+func VerifyContentID(r io.Reader, id string, opts ...ContentOption) (bool, error) {
+	s, err := NewContentID(r, opts...)
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare([]byte(s), []byte(id)) == 1, nil
+}
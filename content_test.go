@@ -0,0 +1,27 @@
+package rid
+
+import (
+	"crypto/sha512"
+	"strings"
+	"testing"
+)
+
+func Test_contentID(t *testing.T) {
+	id, err := NewContentID(strings.NewReader("hello"))
+	if err != nil || len(id) != 22 || !b62regexp.MatchString(id) {
+		t.Fatalf("invalid content ID %s, %v\n", id, err)
+	}
+	if again, _ := NewContentID(strings.NewReader("hello")); again != id {
+		t.Fatalf("content ID not deterministic: %s != %s\n", again, id)
+	}
+	if ok, err := VerifyContentID(strings.NewReader("hello"), id); err != nil || !ok {
+		t.Fatalf("content not verified")
+	}
+	if ok, _ := VerifyContentID(strings.NewReader("hello!"), id); ok {
+		t.Fatalf("other content verified")
+	}
+	long, _ := NewContentID(strings.NewReader("hello"), WithContentHash(sha512.New), WithContentLength(40))
+	if len(long) != 40 || strings.HasSuffix(long, id) {
+		t.Fatalf("unexpected content ID %s\n", long)
+	}
+}
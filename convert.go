@@ -0,0 +1,65 @@
+package rid
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Conversion between number bases, e.g. to render the same ID for different downstream systems
+///////////////////////////////////////////////////////////////////////////
+
+const (
+	// digit order of EncodeBase62 and RIDs
+	Base62Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	// base62 in ASCII order, so that fixed-width renderings sort like the numbers
+	Base62SortableAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	Base36Alphabet         = "0123456789abcdefghijklmnopqrstuvwxyz"
+	Base16Alphabet         = "0123456789abcdef"
+)
+
+var ErrInvalidDigit = errors.New("rid: character not in the source alphabet")
+
+// Convert reads s as a number in fromAlphabet and writes it in toAlphabet, the first character being digit 0.
+// Leading zero digits are kept one for one, so that the conversion round-trips.
+func Convert(s, fromAlphabet, toAlphabet string) (string, error) {
+	if err := checkAlphabet(fromAlphabet); err != nil {
+		return "", err
+	}
+	if err := checkAlphabet(toAlphabet); err != nil {
+		return "", err
+	}
+	var from, to = big.NewInt(int64(len(fromAlphabet))), big.NewInt(int64(len(toAlphabet)))
+	var index [256]int
+	for i := range index {
+		index[i] = -1
+	}
+	for i := 0; i < len(fromAlphabet); i++ {
+		index[fromAlphabet[i]] = i
+	}
+	var zeros = 0
+	for zeros < len(s) && s[zeros] == fromAlphabet[0] {
+		zeros++
+	}
+	var x = new(big.Int)
+	for i := zeros; i < len(s); i++ {
+		var d = index[s[i]]
+		if d < 0 {
+			return "", ErrInvalidDigit
+		}
+		x.Mul(x, from)
+		x.Add(x, big.NewInt(int64(d)))
+	}
+	var out []byte
+	var r = new(big.Int)
+	for x.Sign() > 0 {
+		x.DivMod(x, to, r)
+		out = append(out, toAlphabet[r.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		out = append(out, toAlphabet[0])
+	}
+	slices.Reverse(out)
+	return string(out), nil
+}
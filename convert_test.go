@@ -0,0 +1,26 @@
+package rid
+
+import (
+	"testing"
+)
+
+func Test_convert(t *testing.T) {
+	if s, err := Convert("ff", Base16Alphabet, Base36Alphabet); err != nil || s != "73" {
+		t.Fatalf("unexpected conversion of 0xff: %s, %v\n", s, err)
+	}
+	if s, _ := Convert("00ff", Base16Alphabet, "01"); s != "0011111111" {
+		t.Fatalf("leading zeros not kept: %s\n", s)
+	}
+	if Base62Alphabet != string(B62ascii) {
+		t.Fatalf("Base62Alphabet differs from B62ascii")
+	}
+	var id = NewRID20()
+	h, _ := Convert(id, Base62Alphabet, Base16Alphabet)
+	back, err := Convert(h, Base16Alphabet, Base62Alphabet)
+	if err != nil || back != id {
+		t.Fatalf("round trip failed: %s -> %s -> %s, %v\n", id, h, back, err)
+	}
+	if _, err = Convert("fg", Base16Alphabet, Base36Alphabet); err != ErrInvalidDigit {
+		t.Fatalf("expected ErrInvalidDigit, got %v\n", err)
+	}
+}
@@ -0,0 +1,73 @@
+package rid
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Coupon and gift codes, uppercase without look-alikes, screened for profanity, with a check char
+///////////////////////////////////////////////////////////////////////////
+
+// uppercase part of UnambiguousAlphabet, 4.7 bits per char
+const CouponAlphabet = "ACDEFGHJKLMNPQRTUVWXY34679"
+
+var couponGenerator = New(WithAlphabet(CouponAlphabet), WithFilter(func(id string) bool {
+	return ContainsProfanity(id + string(luhnModN(id, CouponAlphabet)))
+}))
+
+// NewCoupon returns n random chars followed by a Luhn mod 26 check char
+func NewCoupon(n int) string {
+	code, err := NewCouponE(n)
+	if err != nil {
+		//severe error - looks like a failure of random number generator
+		log.Fatal(err)
+	}
+	return code
+}
+
+func NewCouponE(n int) (string, error) {
+	id, err := couponGenerator.NewIDn(n)
+	if err != nil {
+		return "", err
+	}
+	return id + string(luhnModN(id, CouponAlphabet)), nil
+}
+
+// NewCouponBatch returns count distinct coupons of n random chars. Because of the check char,
+// no two valid coupons differ in a single char, so a mistyped coupon never redeems another one.
+// The batch must stay well below the code space, at most a millionth of it, to keep guessing impractical.
+func NewCouponBatch(n, count int) ([]string, error) {
+	if float64(count) > math.Pow(float64(len(CouponAlphabet)), float64(n))/1e6 {
+		return nil, fmt.Errorf("rid: %d coupons of %d chars are too easy to guess, use longer coupons", count, n)
+	}
+	var seen = make(map[string]bool, count)
+	var codes = make([]string, 0, count)
+	for len(codes) < count {
+		code, err := NewCouponE(n)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+	return codes, nil
+}
+
+// ValidCoupon checks a coupon of n random chars. Lowercase, hyphens and whitespace are accepted.
+func ValidCoupon(code string, n int) bool {
+	code = strings.ToUpper(Normalize(code))
+	if len(code) != n+1 {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		if strings.IndexByte(CouponAlphabet, code[i]) < 0 {
+			return false
+		}
+	}
+	return luhnModN(code[:n], CouponAlphabet) == code[n]
+}
@@ -0,0 +1,38 @@
+package rid
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_coupon(t *testing.T) {
+	var code = NewCoupon(8)
+	if len(code) != 9 || !ValidCoupon(code, 8) || !ValidCoupon(strings.ToLower(Group(code, 3, '-')), 8) {
+		t.Fatalf("invalid coupon %s\n", code)
+	}
+	var b = []byte(code)
+	b[0] = CouponAlphabet[(strings.IndexByte(CouponAlphabet, b[0])+1)%len(CouponAlphabet)]
+	if ValidCoupon(string(b), 8) {
+		t.Fatalf("typo not detected: %s\n", b)
+	}
+	if strings.ContainsAny(code, Ambiguous) {
+		t.Fatalf("ambiguous character in %s\n", code)
+	}
+}
+
+func Test_couponBatch(t *testing.T) {
+	codes, err := NewCouponBatch(8, 100000)
+	if err != nil || len(codes) != 100000 {
+		t.Fatalf("NewCouponBatch = %d codes, %v\n", len(codes), err)
+	}
+	var seen = make(map[string]bool)
+	for _, c := range codes {
+		if seen[c] || !ValidCoupon(c, 8) {
+			t.Fatalf("duplicate or invalid coupon %s\n", c)
+		}
+		seen[c] = true
+	}
+	if _, err = NewCouponBatch(4, 10000); err == nil {
+		t.Fatalf("batch too dense for the code space accepted")
+	}
+}
@@ -0,0 +1,89 @@
+package rid
+
+import (
+	"errors"
+	"strings"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Crockford base32, meant to be read aloud and typed back by humans
+///////////////////////////////////////////////////////////////////////////
+
+const CrockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// the 5 extra symbols are only used for the check symbol
+const crockfordCheckSymbols = CrockfordAlphabet + "*~$=U"
+
+var crockford = newCharset(CrockfordAlphabet)
+
+var ErrInvalidCrockford = errors.New("rid: invalid Crockford base32")
+
+// 5 bits of entropy per character
+func NewCrockford(n int) string {
+	return crockford.newString(n)
+}
+
+// n random symbols followed by the check symbol
+func NewCrockfordCheck(n int) string {
+	var s = crockford.newString(n)
+	c, _ := CrockfordCheckSymbol(s)
+	return s + string(c)
+}
+
+// CrockfordCheckSymbol is the value of s modulo 37, encoded with the check symbols.
+// s is normalized as by NormalizeCrockford, false if it is not Crockford base32.
+func CrockfordCheckSymbol(s string) (byte, bool) {
+	n, err := NormalizeCrockford(s)
+	if err != nil || !crockford.valid(n) {
+		return 0, false
+	}
+	var v = 0
+	for i := 0; i < len(n); i++ {
+		v = (v*32 + strings.IndexByte(CrockfordAlphabet, n[i])) % 37
+	}
+	return crockfordCheckSymbols[v], true
+}
+
+// NormalizeCrockford uppercases s, drops hyphens and resolves the aliases I, L -> 1 and O -> 0.
+// The check symbols are accepted only in the last position.
+func NormalizeCrockford(s string) (string, error) {
+	var b = make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		var c = s[i]
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		switch c {
+		case '-':
+			continue
+		case 'I', 'L':
+			c = '1'
+		case 'O':
+			c = '0'
+		}
+		if !crockford.set[c] && (i != len(s)-1 || strings.IndexByte(crockfordCheckSymbols, c) < 0) {
+			return "", ErrInvalidCrockford
+		}
+		b = append(b, c)
+	}
+	if len(b) == 0 {
+		return "", ErrInvalidCrockford
+	}
+	return string(b), nil
+}
+
+// lenient, accepts what NormalizeCrockford accepts, without check symbol
+func ValidCrockford(s string) bool {
+	n, err := NormalizeCrockford(s)
+	return err == nil && crockford.valid(n)
+}
+
+// lenient as ValidCrockford, the last symbol must be the check symbol of the rest
+func ValidCrockfordCheck(s string) bool {
+	n, err := NormalizeCrockford(s)
+	if err != nil || len(n) < 2 {
+		return false
+	}
+	c, ok := CrockfordCheckSymbol(n[:len(n)-1])
+	return ok && c == n[len(n)-1]
+}
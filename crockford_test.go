@@ -0,0 +1,36 @@
+package rid
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_crockford(t *testing.T) {
+	var id = NewCrockfordCheck(12)
+	if len(id) != 13 || !ValidCrockford(id[:12]) || !ValidCrockfordCheck(id) {
+		t.Fatalf("invalid crockford id: %s\n", id)
+	}
+	if !ValidCrockfordCheck(strings.ToLower(id[:4] + "-" + id[4:])) {
+		t.Fatalf("lowercase grouped id should be accepted: %s\n", id)
+	}
+	// 1234 = 0x4D2, base32 "16J", 1234 % 37 = 13 -> "D"
+	for _, s := range []string{"16J", "i6j", "1-6J"} {
+		if c, ok := CrockfordCheckSymbol(s); !ok || c != 'D' {
+			t.Fatalf("unexpected check symbol of %s: %c, %v\n", s, c, ok)
+		}
+	}
+	if !ValidCrockfordCheck("i6jD") {
+		t.Fatalf("i6jD should be valid\n")
+	}
+	for _, s := range []string{"", "16U", "1*J", "16#", "ü"} {
+		if _, ok := CrockfordCheckSymbol(s); ok {
+			t.Fatalf("check symbol of invalid %q\n", s)
+		}
+	}
+	if n, err := NormalizeCrockford("oIl-u"); err != nil || n != "0"+"11U" {
+		t.Fatalf("unexpected normalization: %s, %v\n", n, err)
+	}
+	if ValidCrockford("U123") || ValidCrockfordCheck("16JE") {
+		t.Fatalf("crockford validators too lenient")
+	}
+}
@@ -0,0 +1,47 @@
+package rid
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"io"
+	"log"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// CSRF tokens, one secret per session, sent masked with a fresh XOR mask on every response
+// so that compression side channels (BREACH) can't recover it
+///////////////////////////////////////////////////////////////////////////
+
+const csrfTokenBytes = 32
+
+// NewCSRFToken returns the per-session secret, 32 bytes from crypto/rand in base64url. Keep it in the session.
+func NewCSRFToken() string {
+	return NewToken(csrfTokenBytes)
+}
+
+// MaskCSRFToken returns base64url of a random mask followed by token XOR mask, different on every call.
+// Put the masked token in pages and forms.
+func MaskCSRFToken(token string) string {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != csrfTokenBytes {
+		panic("rid: not a CSRF token")
+	}
+	var b = make([]byte, 2*csrfTokenBytes)
+	if _, err = io.ReadFull(cryptoReader(), b[:csrfTokenBytes]); err != nil {
+		//severe error - looks like a failure of system random number generator
+		log.Fatal(err)
+	}
+	subtle.XORBytes(b[csrfTokenBytes:], raw, b[:csrfTokenBytes])
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// VerifyCSRFToken checks a masked token from a request against the session token in constant time
+func VerifyCSRFToken(token, masked string) bool {
+	raw, err1 := base64.RawURLEncoding.DecodeString(token)
+	b, err2 := base64.RawURLEncoding.DecodeString(masked)
+	if err1 != nil || err2 != nil || len(raw) != csrfTokenBytes || len(b) != 2*csrfTokenBytes {
+		return false
+	}
+	subtle.XORBytes(b[csrfTokenBytes:], b[csrfTokenBytes:], b[:csrfTokenBytes])
+	return subtle.ConstantTimeCompare(b[csrfTokenBytes:], raw) == 1
+}
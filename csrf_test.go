@@ -0,0 +1,19 @@
+package rid
+
+import (
+	"testing"
+)
+
+func Test_csrf(t *testing.T) {
+	var token = NewCSRFToken()
+	var m1, m2 = MaskCSRFToken(token), MaskCSRFToken(token)
+	if m1 == m2 || len(m1) != 86 {
+		t.Fatalf("masked tokens not fresh: %s %s\n", m1, m2)
+	}
+	if !VerifyCSRFToken(token, m1) || !VerifyCSRFToken(token, m2) {
+		t.Fatalf("masked token not verified")
+	}
+	if VerifyCSRFToken(NewCSRFToken(), m1) || VerifyCSRFToken(token, token) || VerifyCSRFToken(token, m2[:43]+m1[43:]) {
+		t.Fatalf("wrong CSRF token verified")
+	}
+}
@@ -0,0 +1,74 @@
+package rid
+
+import (
+	"crypto/sha3"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// cuid2, a random lowercase letter followed by the base36 SHA3-512 hash of time, salt, counter and fingerprint.
+// Compatible with the @paralleldrive/cuid2 package.
+///////////////////////////////////////////////////////////////////////////
+
+const (
+	Cuid2DefaultLength = 24
+	Cuid2MinLength     = 2
+	Cuid2MaxLength     = 32
+)
+
+var ErrCuid2Length = errors.New("rid: cuid2 length out of range 2..32")
+
+// starts at a random value below 476782367 like cuid2
+var cuid2Counter = func() *atomic.Uint64 {
+	var c = new(atomic.Uint64)
+	c.Store(uint64(binary.BigEndian.Uint32(randomBytes(4)) % 476782367))
+	return c
+}()
+
+// host and process specific, mixed with random salt so that it doesn't identify the host
+var cuid2Fingerprint = func() string {
+	hostname, _ := os.Hostname()
+	return cuid2Hash(hostname + strconv.Itoa(os.Getpid()) + NewRIDnLower(32))[:32]
+}()
+
+func NewCuid2() string {
+	id, _ := NewCuid2n(Cuid2DefaultLength)
+	return id
+}
+
+func NewCuid2n(n int) (string, error) {
+	if n < Cuid2MinLength || n > Cuid2MaxLength {
+		return "", ErrCuid2Length
+	}
+	var input = strconv.FormatInt(time.Now().UnixMilli(), 36) +
+		NewRIDnLower(n) +
+		strconv.FormatUint(cuid2Counter.Add(1)-1, 36) +
+		cuid2Fingerprint
+	var first = "abcdefghijklmnopqrstuvwxyz"[randomInt(26)]
+	return string(first) + cuid2Hash(input)[1:n], nil
+}
+
+// base36 of the SHA3-512 digest without its first, biased, digit
+func cuid2Hash(s string) string {
+	var sum = sha3.Sum512([]byte(s))
+	return new(big.Int).SetBytes(sum[:]).Text(36)[1:]
+}
+
+// lowercase letter followed by lowercase base36, 2 to 32 chars in total
+func ValidCuid2(s string) bool {
+	if len(s) < Cuid2MinLength || len(s) > Cuid2MaxLength || s[0] < 'a' || s[0] > 'z' {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if (s[i] < '0' || s[i] > '9') && (s[i] < 'a' || s[i] > 'z') {
+			return false
+		}
+	}
+	return true
+}
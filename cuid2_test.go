@@ -0,0 +1,30 @@
+package rid
+
+import (
+	"testing"
+)
+
+func Test_cuid2(t *testing.T) {
+	var seen = make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		var id = NewCuid2()
+		if len(id) != 24 || !ValidCuid2(id) {
+			t.Fatalf("invalid cuid2 %s\n", id)
+		}
+		if seen[id] {
+			t.Fatalf("duplicate cuid2 %s\n", id)
+		}
+		seen[id] = true
+	}
+	for _, n := range []int{2, 10, 32} {
+		if id, err := NewCuid2n(n); err != nil || len(id) != n || !ValidCuid2(id) {
+			t.Fatalf("invalid cuid2 of length %d: %s, %v\n", n, id, err)
+		}
+	}
+	if _, err := NewCuid2n(33); err != ErrCuid2Length {
+		t.Fatalf("length 33 should be rejected")
+	}
+	if ValidCuid2("1abc") || ValidCuid2("aBc") || ValidCuid2("a") {
+		t.Fatalf("invalid cuid2 accepted")
+	}
+}
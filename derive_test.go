@@ -0,0 +1,28 @@
+package rid
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_deriveRID(t *testing.T) {
+	var id = DeriveRID("secret", "customer-4711", 20)
+	if !ValidRID20(id) || id != DeriveRID("secret", "customer-4711", 20) {
+		t.Fatalf("derived RID not stable: %s\n", id)
+	}
+	if id == DeriveRID("other", "customer-4711", 20) || id == DeriveRID("secret", "customer-4712", 20) {
+		t.Fatalf("derived RID independent of secret or input")
+	}
+	if short := DeriveRID("secret", "customer-4711", 8); !strings.HasPrefix(id, short) {
+		t.Fatalf("shorter derived RID %s not a prefix of %s\n", short, id)
+	}
+}
+
+// the derivation must never change, or stored references break
+const deriveGolden = "UyzRV2Co8mlr2xT2ARlA"
+
+func Test_deriveRIDGolden(t *testing.T) {
+	if id := DeriveRID("secret", "customer-4711", 20); id != deriveGolden {
+		t.Fatalf("derived RID changed: %s\n", id)
+	}
+}
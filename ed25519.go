@@ -0,0 +1,33 @@
+package rid
+
+import (
+	"crypto/ed25519"
+	"math/big"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Ed25519 signed RIDs, verifiable with the public key only.
+// 20 base62 characters followed by the 64-byte signature in 86 base62 characters.
+///////////////////////////////////////////////////////////////////////////
+
+const ed25519SigChars = 86
+
+func NewRIDSignedEd25519(priv ed25519.PrivateKey) string {
+	r := NewRID20()
+	s, _ := intToDigits(new(big.Int).SetBytes(ed25519.Sign(priv, []byte(r))), Base62Alphabet, ed25519SigChars)
+	return r + s
+}
+
+// false for a pub of the wrong size, on which ed25519.Verify would panic
+func ValidRIDSignedEd25519(id string, pub ed25519.PublicKey) bool {
+	if len(pub) != ed25519.PublicKeySize || len(id) != 20+ed25519SigChars || !ValidRID20(id[:20]) {
+		return false
+	}
+	x, ok := digitsToInt(id[20:], Base62Alphabet)
+	if !ok || x.BitLen() > 8*ed25519.SignatureSize {
+		return false
+	}
+	var sig = make([]byte, ed25519.SignatureSize)
+	x.FillBytes(sig)
+	return ed25519.Verify(pub, []byte(id[:20]), sig)
+}
@@ -0,0 +1,28 @@
+package rid
+
+import (
+	"crypto/ed25519"
+	"testing"
+)
+
+func Test_signedEd25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var id = NewRIDSignedEd25519(priv)
+	if len(id) != 106 || !b62regexp.MatchString(id) || !ValidRIDSignedEd25519(id, pub) {
+		t.Fatalf("invalid Ed25519-signed RID %s\n", id)
+	}
+	other, _, _ := ed25519.GenerateKey(nil)
+	if ValidRIDSignedEd25519(id, other) || ValidRIDSignedEd25519(NewRID20()+id[20:], pub) || ValidRIDSignedEd25519(id[:105], pub) {
+		t.Fatalf("Ed25519-signed RID valid with wrong key or ID")
+	}
+	// 86 base62 characters can exceed 512 bits
+	if ValidRIDSignedEd25519(id[:20]+"999999"+id[26:], pub) {
+		t.Fatalf("oversized signature accepted")
+	}
+	if ValidRIDSignedEd25519(id, nil) || ValidRIDSignedEd25519(id, pub[:31]) {
+		t.Fatalf("short public key accepted\n")
+	}
+}
@@ -0,0 +1,54 @@
+package rid
+
+import (
+	"crypto/hmac"
+	"math/big"
+	"time"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Signed RIDs with expiry, for short-lived links: RID20, expiry in 6 base62 characters of Unix seconds
+// and the 16 hex characters of HMAC over both, 42 characters
+///////////////////////////////////////////////////////////////////////////
+
+// prefixed to the MAC input, so other IDs signed with HMAC and the same secret are not valid expiring IDs
+const expiringLabel = "rid expiring id"
+
+// 62^6 seconds reach beyond the year 3700
+const expiryChars = 6
+
+func NewRIDSignedExpiring(secret string, ttl time.Duration) string {
+	return newRIDSignedExpiringAt(secret, time.Now().Add(ttl))
+}
+
+func newRIDSignedExpiringAt(secret string, expires time.Time) string {
+	var s, _ = intToDigits(big.NewInt(max(expires.Unix(), 0)), Base62Alphabet, expiryChars)
+	var r = NewRID20() + s
+	return r + HMAC(expiringLabel+r, secret)
+}
+
+// ValidateRIDSignedExpiring returns the expiry of a NewRIDSignedExpiring ID valid at now.
+// The signature is checked first, so ErrTokenExpired is returned only for genuine IDs.
+func ValidateRIDSignedExpiring(r string, secret string, now time.Time) (time.Time, error) {
+	if len(r) != 20+expiryChars+16 {
+		return time.Time{}, ErrBadLength
+	}
+	var signed = r[:20+expiryChars]
+	if !b62regexp.MatchString(signed) {
+		return time.Time{}, ErrBadAlphabet
+	}
+	if !hmac.Equal([]byte(r[len(signed):]), []byte(HMAC(expiringLabel+signed, secret))) {
+		return time.Time{}, ErrBadSignature
+	}
+	x, _ := digitsToInt(r[20:len(signed)], Base62Alphabet)
+	var expires = time.Unix(x.Int64(), 0)
+	if !now.Before(expires) {
+		return expires, ErrTokenExpired
+	}
+	return expires, nil
+}
+
+func ValidRIDSignedExpiring(r string, secret string) bool {
+	_, err := ValidateRIDSignedExpiring(r, secret, time.Now())
+	return err == nil
+}
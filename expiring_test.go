@@ -0,0 +1,39 @@
+package rid
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_RIDSignedExpiring(t *testing.T) {
+	var now = time.Unix(1_700_000_000, 0)
+	var r = newRIDSignedExpiringAt("secret", now.Add(time.Hour))
+	if len(r) != 42 || !ValidRID20(r[:20]) {
+		t.Fatalf("bad expiring RID %s\n", r)
+	}
+	expires, err := ValidateRIDSignedExpiring(r, "secret", now)
+	if err != nil || !expires.Equal(now.Add(time.Hour)) {
+		t.Fatalf("ValidateRIDSignedExpiring(%s) = %v, %v\n", r, expires, err)
+	}
+	if _, err = ValidateRIDSignedExpiring(r, "secret", now.Add(time.Hour)); err != ErrTokenExpired {
+		t.Fatalf("expired RID gave %v\n", err)
+	}
+	if _, err = ValidateRIDSignedExpiring(r, "other", now); err != ErrBadSignature {
+		t.Fatalf("wrong secret gave %v\n", err)
+	}
+	// moving the expiry breaks the signature
+	var later = newRIDSignedExpiringAt("secret", now.Add(24*time.Hour))
+	if _, err = ValidateRIDSignedExpiring(r[:20]+later[20:26]+r[26:], "secret", now); err != ErrBadSignature {
+		t.Fatalf("extended expiry gave %v\n", err)
+	}
+	// a plain HMAC signature over the same 26 characters is not an expiring ID
+	if _, err = ValidateRIDSignedExpiring(r[:26]+HMAC(r[:26], "secret"), "secret", now); err != ErrBadSignature {
+		t.Fatalf("HMAC without label gave %v\n", err)
+	}
+	if _, err = ValidateRIDSignedExpiring(r[:41], "secret", now); err != ErrBadLength {
+		t.Fatalf("short RID gave %v\n", err)
+	}
+	if !ValidRIDSignedExpiring(NewRIDSignedExpiring("secret", time.Minute), "secret") {
+		t.Fatalf("fresh expiring RID invalid\n")
+	}
+}
@@ -0,0 +1,34 @@
+package rid
+
+import (
+	"crypto/fips140"
+	"errors"
+	"sync/atomic"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// FIPS 140-3 mode: random IDs come straight from crypto/rand, without the internal ChaCha8 shards,
+// and signed IDs use HMAC-SHA256 or another approved MAC only.
+// It is on when set with SetFIPSMode or when the program runs with GODEBUG=fips140=on,
+// which also puts crypto/rand and crypto/hmac into the Go Cryptographic Module.
+//
+// In FIPS mode:
+//   - NewRIDn, AppendRIDn, NewRIDnMath and every Generator without WithRand read crypto/rand
+//   - a reader set with SetRand or WithRand is still used, it must be an approved DRBG, e.g. of an HSM
+//   - SipHash signed RIDs panic with ErrNotFIPSApproved, DeriveRID is approved and unchanged
+//   - MACAlgorithm and SignatureScheme without Approved neither sign nor verify
+//   - DeterministicGenerator is unchanged, it is meant for tests only
+///////////////////////////////////////////////////////////////////////////
+
+var fipsMode atomic.Bool
+
+var ErrNotFIPSApproved = errors.New("rid: algorithm not approved in FIPS mode")
+
+// SetFIPSMode turns FIPS mode on or off for the package, it can't be turned off under GODEBUG=fips140=on
+func SetFIPSMode(on bool) {
+	fipsMode.Store(on)
+}
+
+func FIPSMode() bool {
+	return fipsMode.Load() || fips140.Enabled()
+}
@@ -0,0 +1,64 @@
+package rid
+
+import (
+	"testing"
+)
+
+func internalUses() int {
+	var n = 0
+	for i := range internalRand.shards {
+		var s = &internalRand.shards[i]
+		s.lk.Lock()
+		n += s.uses
+		s.lk.Unlock()
+	}
+	return n
+}
+
+func Test_fipsMode(t *testing.T) {
+	SetFIPSMode(true)
+	defer SetFIPSMode(false)
+	if !FIPSMode() {
+		t.Fatalf("FIPS mode not on\n")
+	}
+	var uses = internalUses()
+	var ids = []string{NewRID20(), NewRIDnMath(20), NewRIDSortable(20), New(WithLength(20)).NewID()}
+	for _, id := range ids {
+		if len(id) != 20 || !b62regexp.MatchString(id) {
+			t.Fatalf("bad FIPS-mode ID %s\n", id)
+		}
+	}
+	if internalUses() != uses {
+		t.Fatalf("internal generator used in FIPS mode\n")
+	}
+	if !ValidRID20Signed(NewRID20Signed("secret"), "secret") {
+		t.Fatalf("HMAC-signed RID invalid in FIPS mode\n")
+	}
+	// algorithms not approved are refused, both signing and verifying
+	var alg = &MACAlgorithm{Code: 'y', Name: "not approved", New: HMACSHA256.New}
+	var scheme = lookupSignatureScheme('y')
+	if scheme == nil {
+		scheme = &SignatureScheme{Version: 'y', Name: "not approved", Length: 16, Sign: HMAC}
+		RegisterSignatureScheme(scheme)
+	}
+	var g = New(WithSigner("secret"), WithMAC(alg))
+	SetFIPSMode(false)
+	var id, r = g.NewID(), NewRID20SignedScheme(scheme, "secret")
+	SetFIPSMode(true)
+	if _, err := g.NewIDE(); err != ErrNotFIPSApproved || g.Valid(id) {
+		t.Fatalf("not approved MAC used in FIPS mode: %v\n", err)
+	}
+	if err := VerifySignedRIDVersioned(r, "secret"); err != ErrNotFIPSApproved {
+		t.Fatalf("not approved scheme verified in FIPS mode: %v\n", err)
+	}
+	// DeriveRID is approved and stable, SipHash fails loudly
+	if d := DeriveRID("secret", "customer-4711", 20); d != deriveGolden {
+		t.Fatalf("derived RID %s changed in FIPS mode\n", d)
+	}
+	defer func() {
+		if recover() != ErrNotFIPSApproved {
+			t.Fatalf("SipHash-signed RID accepted in FIPS mode\n")
+		}
+	}()
+	NewRID20SignedSip([16]byte{1, 2, 3})
+}
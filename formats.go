@@ -0,0 +1,278 @@
+package rid
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Alternative alphabets, generated with the same source and sampling as NewRIDn
+///////////////////////////////////////////////////////////////////////////
+
+type charset struct {
+	chars []byte
+	set   [256]bool
+}
+
+func newCharset(chars string) *charset {
+	if err := checkAlphabet(chars); err != nil {
+		panic(err)
+	}
+	var c = &charset{chars: []byte(chars)}
+	for i := 0; i < len(chars); i++ {
+		c.set[chars[i]] = true
+	}
+	return c
+}
+
+func (c *charset) newString(n int) string {
+	b, err := appendAlphabet(make([]byte, 0, n), packageReader(), c.chars, n)
+	if err != nil {
+		//severe error - looks like a failure of random number generator
+		log.Fatal(err)
+	}
+	return string(b)
+}
+
+// non-empty and only characters from the set
+func (c *charset) valid(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !c.set[s[i]] {
+			return false
+		}
+	}
+	return true
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Base58, Bitcoin alphabet without the look-alikes 0, O, I and l
+///////////////////////////////////////////////////////////////////////////
+
+const B58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58 = newCharset(B58Alphabet)
+
+// 20-chars of base58 gives 117.2 bits of entropy
+func NewRIDnBase58(n int) string {
+	return base58.newString(n)
+}
+
+func ValidBase58(s string) bool {
+	return base58.valid(s)
+}
+
+///////////////////////////////////////////////////////////////////////////
+// z-base-32, the human-oriented base32 ordering used by Tahoe-LAFS
+///////////////////////////////////////////////////////////////////////////
+
+const ZBase32Alphabet = "ybndrfg8ejkmcpqxot1uwisza345h769"
+
+var zbase32 = newCharset(ZBase32Alphabet)
+
+// 5 bits of entropy per character
+func NewZBase32(n int) string {
+	return zbase32.newString(n)
+}
+
+func ValidZBase32(s string) bool {
+	return zbase32.valid(s)
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Lowercase hex
+///////////////////////////////////////////////////////////////////////////
+
+var hexChars = newCharset("0123456789abcdef")
+
+// 4 bits of entropy per character, 32-chars gives 128 bits
+func NewHexID(n int) string {
+	return hexChars.newString(n)
+}
+
+// lowercase only
+func ValidHexID(s string) bool {
+	return hexChars.valid(s)
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Lowercase base36, for case-insensitive stores (MySQL default collation, DNS)
+// where "Abc1" and "abc1" collide and base62 silently loses entropy
+///////////////////////////////////////////////////////////////////////////
+
+var base36Lower = newCharset("abcdefghijklmnopqrstuvwxyz0123456789")
+
+// 5.17 bits of entropy per character, use Base36LengthFor to keep the entropy of a base62 RID
+func NewRIDnLower(n int) string {
+	return base36Lower.newString(n)
+}
+
+// 24-chars of base36 gives 124.1 bits of entropy, at least as much as RID20
+func NewRID20Lower() string {
+	return NewRIDnLower(Base36LengthFor(20))
+}
+
+// rejects any uppercase character
+func ValidRIDLower(s string) bool {
+	return base36Lower.valid(s)
+}
+
+// Base36LengthFor returns the base36 length carrying at least the entropy of n base62 characters
+func Base36LengthFor(n int) int {
+	return int(math.Ceil(float64(n) * math.Log(62) / math.Log(36)))
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Uppercase base36, for mainframes and printed labels
+///////////////////////////////////////////////////////////////////////////
+
+var base36Upper = newCharset("ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+
+// 5.17 bits of entropy per character, use Base36LengthFor to keep the entropy of a base62 RID
+func NewRIDnUpper(n int) string {
+	return base36Upper.newString(n)
+}
+
+// 24-chars of base36 gives 124.1 bits of entropy, at least as much as RID20
+func NewRID20Upper() string {
+	return NewRIDnUpper(Base36LengthFor(20))
+}
+
+// rejects any lowercase character
+func ValidRIDUpper(s string) bool {
+	return base36Upper.valid(s)
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Alphabets without visually confusable characters
+///////////////////////////////////////////////////////////////////////////
+
+// characters easily mistaken for each other in print or handwriting: 0/O/o, 1/I/l, 5/S/s, 2/Z/z, 8/B
+const Ambiguous = "0Oo1Il5Ss2Zz8B"
+
+// base62 without Ambiguous, 48 characters giving 5.58 bits of entropy per character (base62 gives 5.95)
+const UnambiguousAlphabet = "ACDEFGHJKLMNPQRTUVWXYabcdefghijkmnpqrtuvwxy34679"
+
+// WithoutAmbiguous removes the Ambiguous characters from the generator's alphabet.
+// It applies to the alphabet set so far, so put it after WithAlphabet. Use Generator.Entropy to see the result.
+func WithoutAmbiguous() Option {
+	return WithoutChars(Ambiguous)
+}
+
+// WithoutChars removes chars from the generator's alphabet, at least 2 characters must remain
+func WithoutChars(chars string) Option {
+	return func(g *Generator) {
+		var alphabet []byte
+		for _, c := range g.alphabet {
+			if strings.IndexByte(chars, c) < 0 {
+				alphabet = append(alphabet, c)
+			}
+		}
+		if len(alphabet) < 2 {
+			panic(fmt.Sprintf("rid: removing %q leaves less than 2 characters", chars))
+		}
+		g.alphabet = alphabet
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Alphabets that can't spell words, for IDs in customer-facing URLs
+///////////////////////////////////////////////////////////////////////////
+
+const Vowels = "AEIOUaeiou"
+
+// digits that read as letters in leetspeak: 0/O, 1/I, 3/E
+const LeetDigits = "013"
+
+// WithoutVowels removes Vowels from the generator's alphabet.
+// From base62 it leaves 52 characters, 5.70 bits of entropy per character.
+func WithoutVowels() Option {
+	return WithoutChars(Vowels)
+}
+
+// WithoutLeetDigits removes LeetDigits from the generator's alphabet.
+// Together with WithoutVowels it leaves 49 characters of base62, 5.61 bits of entropy per character.
+func WithoutLeetDigits() Option {
+	return WithoutChars(LeetDigits)
+}
+
+///////////////////////////////////////////////////////////////////////////
+// DNS labels, for per-tenant subdomains
+///////////////////////////////////////////////////////////////////////////
+
+var lowerLetters = newCharset("abcdefghijklmnopqrstuvwxyz")
+
+// NewDNSLabel returns a lowercase alphanumeric label starting with a letter.
+// n is capped at 63, the maximum label length.
+func NewDNSLabel(n int) string {
+	if n > 63 {
+		n = 63
+	}
+	if n < 1 {
+		n = 1
+	}
+	return lowerLetters.newString(1) + base36Lower.newString(n-1)
+}
+
+// ValidDNSLabel checks an RFC 1123 label in the lowercase form used by Kubernetes:
+// 1 to 63 characters of [a-z0-9-], starting and ending with an alphanumeric
+func ValidDNSLabel(s string) bool {
+	if len(s) == 0 || len(s) > 63 || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	return base36Lower.valid(strings.ReplaceAll(s, "-", "a"))
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Filenames, safe on case-insensitive file systems and on Windows
+///////////////////////////////////////////////////////////////////////////
+
+// maximum file name length on common file systems
+const MaxFilenameLength = 255
+
+// names Windows reserves regardless of extension
+var windowsReserved = map[string]bool{
+	"con": true, "prn": true, "aux": true, "nul": true,
+	"com1": true, "com2": true, "com3": true, "com4": true, "com5": true, "com6": true, "com7": true, "com8": true, "com9": true,
+	"lpt1": true, "lpt2": true, "lpt3": true, "lpt4": true, "lpt5": true, "lpt6": true, "lpt7": true, "lpt8": true, "lpt9": true,
+}
+
+// NewFilenameID returns lowercase base36, so that IDs never differ by case only (macOS, Windows),
+// and never a reserved Windows device name. n is capped at MaxFilenameLength.
+func NewFilenameID(n int) string {
+	if n > MaxFilenameLength {
+		n = MaxFilenameLength
+	}
+	for {
+		var id = base36Lower.newString(n)
+		if !windowsReserved[id] {
+			return id
+		}
+	}
+}
+
+func ValidFilenameID(s string) bool {
+	return len(s) <= MaxFilenameLength && base36Lower.valid(s) && !windowsReserved[s]
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Kubernetes name suffixes
+///////////////////////////////////////////////////////////////////////////
+
+// same alphabet as k8s.io/apimachinery/pkg/util/rand, no vowels and no 0, 1, 3 to avoid bad words
+const K8sAlphabet = "bcdfghjklmnpqrstvwxz2456789"
+
+var k8sChars = newCharset(K8sAlphabet)
+
+// NewK8sSuffix returns a 5-char suffix as used for generated pod names, 23.8 bits of entropy
+func NewK8sSuffix() string {
+	return k8sChars.newString(5)
+}
+
+func ValidK8sSuffix(s string) bool {
+	return len(s) == 5 && k8sChars.valid(s)
+}
@@ -0,0 +1,125 @@
+package rid
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func Test_base58(t *testing.T) {
+	var id = NewRIDnBase58(20)
+	if !regexp.MustCompile(`^[1-9A-HJ-NP-Za-km-z]{20}$`).MatchString(id) || !ValidBase58(id) {
+		t.Fatalf("invalid base58 id: %s\n", id)
+	}
+	if ValidBase58("abc0") || ValidBase58("") {
+		t.Fatalf("base58 validator too lenient")
+	}
+}
+
+func Test_zbase32(t *testing.T) {
+	var id = NewZBase32(26)
+	if len(id) != 26 || !ValidZBase32(id) {
+		t.Fatalf("invalid z-base-32 id: %s\n", id)
+	}
+	if ValidZBase32("abcv") || ValidZBase32("ABC") {
+		t.Fatalf("z-base-32 validator too lenient")
+	}
+}
+
+func Test_hexID(t *testing.T) {
+	var id = NewHexID(32)
+	if !regexp.MustCompile(`^[0-9a-f]{32}$`).MatchString(id) || !ValidHexID(id) {
+		t.Fatalf("invalid hex id: %s\n", id)
+	}
+	if ValidHexID("ABCDEF") || ValidHexID("g0") {
+		t.Fatalf("hex validator too lenient")
+	}
+}
+
+func Test_lower(t *testing.T) {
+	if Base36LengthFor(20) != 24 || Base36LengthFor(16) != 19 {
+		t.Fatalf("unexpected base36 lengths: %d, %d\n", Base36LengthFor(20), Base36LengthFor(16))
+	}
+	var id = NewRID20Lower()
+	if !regexp.MustCompile(`^[a-z0-9]{24}$`).MatchString(id) || !ValidRIDLower(id) {
+		t.Fatalf("invalid lowercase id: %s\n", id)
+	}
+	if ValidRIDLower("abcD") {
+		t.Fatalf("mixed case should be rejected")
+	}
+}
+
+func Test_upper(t *testing.T) {
+	var id = NewRID20Upper()
+	if !regexp.MustCompile(`^[A-Z0-9]{24}$`).MatchString(id) || !ValidRIDUpper(id) {
+		t.Fatalf("invalid uppercase id: %s\n", id)
+	}
+	if ValidRIDUpper("ABCd") {
+		t.Fatalf("mixed case should be rejected")
+	}
+}
+
+func Test_unambiguous(t *testing.T) {
+	var g = New(WithoutAmbiguous())
+	if string(g.alphabet) != UnambiguousAlphabet {
+		t.Fatalf("unexpected alphabet: %s\n", g.alphabet)
+	}
+	var id = g.NewID()
+	if strings.ContainsAny(id, Ambiguous) || !g.Valid(id) {
+		t.Fatalf("ambiguous characters in id: %s\n", id)
+	}
+	if e := g.Entropy(); e < 111.6 || e > 111.8 {
+		t.Fatalf("unexpected entropy: %.2f\n", e)
+	}
+}
+
+func Test_withoutVowels(t *testing.T) {
+	var g = New(WithoutVowels(), WithoutLeetDigits())
+	if len(g.alphabet) != 49 {
+		t.Fatalf("unexpected alphabet: %s\n", g.alphabet)
+	}
+	for i := 0; i < 100; i++ {
+		if id := g.NewID(); strings.ContainsAny(id, Vowels+LeetDigits) {
+			t.Fatalf("vowel or leet digit in id: %s\n", id)
+		}
+	}
+}
+
+func Test_dnsLabel(t *testing.T) {
+	var id = NewDNSLabel(100)
+	if len(id) != 63 || id[0] < 'a' || id[0] > 'z' || !ValidDNSLabel(id) {
+		t.Fatalf("invalid dns label: %s\n", id)
+	}
+	for _, s := range []string{"a-b", "0ab", "x"} {
+		if !ValidDNSLabel(s) {
+			t.Fatalf("label %s should be valid\n", s)
+		}
+	}
+	for _, s := range []string{"", "-ab", "ab-", "Ab", "a_b", strings.Repeat("a", 64)} {
+		if ValidDNSLabel(s) {
+			t.Fatalf("label %s should be invalid\n", s)
+		}
+	}
+}
+
+func Test_filenameID(t *testing.T) {
+	var id = NewFilenameID(300)
+	if len(id) != MaxFilenameLength || !ValidFilenameID(id) {
+		t.Fatalf("invalid filename id: %s\n", id)
+	}
+	for _, s := range []string{"con", "lpt1", "Abc", ""} {
+		if ValidFilenameID(s) {
+			t.Fatalf("filename %s should be invalid\n", s)
+		}
+	}
+}
+
+func Test_k8sSuffix(t *testing.T) {
+	var id = NewK8sSuffix()
+	if !ValidK8sSuffix(id) || strings.ContainsAny(id, "aeiou013") {
+		t.Fatalf("invalid k8s suffix: %s\n", id)
+	}
+	if ValidK8sSuffix("bcdfa") || ValidK8sSuffix("bcdf") {
+		t.Fatalf("k8s suffix validator too lenient")
+	}
+}
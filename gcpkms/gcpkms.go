@@ -0,0 +1,60 @@
+// Package gcpkms signs and verifies rid.NewRIDSignedWith IDs with Google Cloud KMS HMAC_SHA256 key versions,
+// so the MAC keys never leave KMS. Importing it pulls in the Cloud KMS client, the rid package doesn't.
+package gcpkms
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/kms/apiv1/kmspb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/seckiss/rid"
+)
+
+// Client is the part of *kms.KeyManagementClient used here
+type Client interface {
+	MacSign(ctx context.Context, req *kmspb.MacSignRequest, opts ...gax.CallOption) (*kmspb.MacSignResponse, error)
+	MacVerify(ctx context.Context, req *kmspb.MacVerifyRequest, opts ...gax.CallOption) (*kmspb.MacVerifyResponse, error)
+}
+
+// Signer signs with the key version Current and verifies with any key version of Keys.
+// Keys maps the key IDs embedded in signed IDs, 1 to 8 base62 characters, to key version resource names,
+// projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/v.
+type Signer struct {
+	Client  Client
+	Keys    map[string]string
+	Current string
+}
+
+var (
+	_ rid.Signer   = (*Signer)(nil)
+	_ rid.Verifier = (*Signer)(nil)
+)
+
+func (s *Signer) Sign(ctx context.Context, message []byte) (string, []byte, error) {
+	var name, ok = s.Keys[s.Current]
+	if !ok {
+		return "", nil, fmt.Errorf("gcpkms: no key version for current key ID %q", s.Current)
+	}
+	resp, err := s.Client.MacSign(ctx, &kmspb.MacSignRequest{Name: name, Data: message})
+	if err != nil {
+		return "", nil, err
+	}
+	return s.Current, resp.Mac, nil
+}
+
+// Verify returns rid.ErrBadSignature for an unknown key ID or a wrong MAC, other errors come from KMS
+func (s *Signer) Verify(ctx context.Context, keyID string, message, mac []byte) error {
+	var name, ok = s.Keys[keyID]
+	if !ok {
+		return rid.ErrBadSignature
+	}
+	resp, err := s.Client.MacVerify(ctx, &kmspb.MacVerifyRequest{Name: name, Data: message, Mac: mac})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return rid.ErrBadSignature
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package gcpkms
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"testing"
+
+	"cloud.google.com/go/kms/apiv1/kmspb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/seckiss/rid"
+)
+
+// HMAC-SHA256 keys by key version name
+type fakeKMS map[string][]byte
+
+func (f fakeKMS) mac(name string, data []byte) []byte {
+	var h = hmac.New(sha256.New, f[name])
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func (f fakeKMS) MacSign(_ context.Context, req *kmspb.MacSignRequest, _ ...gax.CallOption) (*kmspb.MacSignResponse, error) {
+	return &kmspb.MacSignResponse{Name: req.Name, Mac: f.mac(req.Name, req.Data)}, nil
+}
+
+func (f fakeKMS) MacVerify(_ context.Context, req *kmspb.MacVerifyRequest, _ ...gax.CallOption) (*kmspb.MacVerifyResponse, error) {
+	return &kmspb.MacVerifyResponse{Name: req.Name, Success: hmac.Equal(f.mac(req.Name, req.Data), req.Mac)}, nil
+}
+
+func Test_signer(t *testing.T) {
+	var ctx = context.Background()
+	var client = fakeKMS{"v1": []byte("one"), "v2": []byte("two")}
+	var s = &Signer{Client: client, Keys: map[string]string{"g1": "v1", "g2": "v2"}, Current: "g1"}
+	id, err := rid.NewRIDSignedWith(ctx, s)
+	if err != nil || id[20:22] != "g1" {
+		t.Fatalf("NewRIDSignedWith = %s, %v\n", id, err)
+	}
+	if err = rid.VerifyRIDSignedWith(ctx, id, s); err != nil {
+		t.Fatalf("VerifyRIDSignedWith(%s) = %v\n", id, err)
+	}
+	if err = rid.VerifyRIDSignedWith(ctx, id[:20]+"g2"+id[22:], s); err != rid.ErrBadSignature {
+		t.Fatalf("ID with swapped key ID gave %v\n", err)
+	}
+	if err = rid.VerifyRIDSignedWith(ctx, id[:20]+"g3"+id[22:], s); err != rid.ErrBadSignature {
+		t.Fatalf("ID with unknown key ID gave %v\n", err)
+	}
+}
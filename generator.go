@@ -0,0 +1,398 @@
+package rid
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/bits"
+	randv2 "math/rand/v2"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Per-instance generator, so that several ID styles can live side by side
+///////////////////////////////////////////////////////////////////////////
+
+type Generator struct {
+	length   int
+	alphabet []byte
+	rand     io.Reader
+	secret   string
+	signed   bool
+	// MAC bytes, 0 means MAC64
+	macLen   int
+	macB62   bool
+	mac      *MACAlgorithm
+	accepted []*MACAlgorithm
+	keys     KeyProvider
+	internal *internalRandType
+	filters  []func(id string) bool
+	// no digit as first character, first holds the non-digits of the alphabet
+	letterFirst bool
+	first       []byte
+	// code embedded in front of every ID and the set of codes accepted by Valid and ShardOf
+	shard  string
+	shards []string
+}
+
+type Option func(*Generator)
+
+// Source is the ID generation interface for dependency injection.
+// It is implemented by Generator and by the generators in package ridtest.
+// NewIDn(n) returns an ID of n characters in total, fixed parts like a shard code, prefix or counter included;
+// only a signature configured on the generator is appended beyond n. It fails if n leaves no room for the fixed parts.
+type Source interface {
+	NewID() string
+	NewIDn(n int) (string, error)
+}
+
+var _ Source = (*Generator)(nil)
+
+// package-level generator, see SetDefault
+var defaultGenerator struct {
+	lk sync.RWMutex
+	g  *Generator
+}
+
+// SetDefault makes NewRID16, NewRID20 and NewRIDn generate with g's alphabet and random source.
+// The length is still determined by the function called and no signature is appended.
+// SetDefault(nil) restores the built-in behavior.
+func SetDefault(g *Generator) {
+	defaultGenerator.lk.Lock()
+	defer defaultGenerator.lk.Unlock()
+	defaultGenerator.g = g
+}
+
+// Default returns the generator set by SetDefault or nil
+func Default() *Generator {
+	defaultGenerator.lk.RLock()
+	defer defaultGenerator.lk.RUnlock()
+	return defaultGenerator.g
+}
+
+// Without options it generates RID20 from the internal generator, same as NewRID20
+func New(opts ...Option) *Generator {
+	var g = &Generator{length: 20, alphabet: B62ascii}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if g.letterFirst {
+		g.first = nonDigits(g.alphabet)
+		if len(g.first) < 2 {
+			panic(fmt.Sprintf("rid: WithLetterFirst needs at least 2 non-digits in alphabet %q", g.alphabet))
+		}
+	}
+	return g
+}
+
+// Reads directly from crypto/rand
+func NewCrypto(opts ...Option) *Generator {
+	return New(append([]Option{WithRand(rand.Reader)}, opts...)...)
+}
+
+func WithLength(n int) Option {
+	return func(g *Generator) {
+		if n < 1 {
+			panic(fmt.Sprintf("rid: invalid length %d", n))
+		}
+		g.length = n
+	}
+}
+
+// alphabet must consist of 2 to 256 distinct bytes
+func WithAlphabet(alphabet string) Option {
+	if err := checkAlphabet(alphabet); err != nil {
+		panic(err)
+	}
+	return func(g *Generator) {
+		g.alphabet = []byte(alphabet)
+	}
+}
+
+// r replaces the internal generator as the source of random bytes
+func WithRand(r io.Reader) Option {
+	return func(g *Generator) {
+		g.rand = r
+	}
+}
+
+// g gets its own internal generator reseeded according to policy, instead of sharing the package one
+func WithReseedPolicy(policy ReseedPolicy) Option {
+	return func(g *Generator) {
+		g.internal = newInternalRand(runtime.GOMAXPROCS(0), policy)
+	}
+}
+
+// IDs get the HMAC of the random part appended, as in NewRID20Signed
+func WithSigner(secret string) Option {
+	return func(g *Generator) {
+		g.secret = secret
+		g.signed = true
+	}
+}
+
+func (g *Generator) NewID() string {
+	id, err := g.NewIDE()
+	if err != nil {
+		//severe error - looks like a failure of random number generator, or a KeyProvider without key
+		log.Fatal(err)
+	}
+	return id
+}
+
+// As NewID but returns the random source failure to the caller
+func (g *Generator) NewIDE() (string, error) {
+	return g.NewIDn(g.length)
+}
+
+// As NewIDE with n characters instead of the configured length, the shard code included
+// and a configured signature appended beyond n, see Source
+func (g *Generator) NewIDn(n int) (string, error) {
+	if n <= len(g.shard) {
+		return "", fmt.Errorf("rid: length %d leaves no room after shard code %q", n, g.shard)
+	}
+	var id string
+	for i := 0; ; i++ {
+		var err error
+		if id, err = g.random(n - len(g.shard)); err != nil {
+			return "", err
+		}
+		id = g.shard + id
+		if !g.rejected(id) {
+			break
+		}
+		if i == maxRetries {
+			return "", ErrTooManyRetries
+		}
+	}
+	if g.signed {
+		sig, err := g.sign(id)
+		if err != nil {
+			return "", err
+		}
+		id += sig
+	}
+	return id, nil
+}
+
+// n random characters from the generator's alphabet, without signature
+func (g *Generator) random(n int) (string, error) {
+	b, err := g.appendRandom(make([]byte, 0, n), n)
+	return string(b), err
+}
+
+func (g *Generator) appendRandom(dst []byte, n int) ([]byte, error) {
+	if g.letterFirst && n > 0 {
+		var first, err = appendAlphabet(dst, g.reader(), g.first, 1)
+		if err != nil {
+			return dst, err
+		}
+		return appendAlphabet(first, g.reader(), g.alphabet, n-1)
+	}
+	return appendAlphabet(dst, g.reader(), g.alphabet, n)
+}
+
+// WithLetterFirst makes IDs never begin with a digit, for CSS identifiers, language symbols and DB tools.
+// For base62 the first character has 52 instead of 62 options, a loss of 0.25 bits per ID.
+// The alphabet must contain at least 2 non-digits.
+func WithLetterFirst() Option {
+	return func(g *Generator) {
+		g.letterFirst = true
+	}
+}
+
+// WithShard reserves the first characters of every ID for code, e.g. a home region, out of the set of codes.
+// All codes must have the same length, which is taken from the configured length, and code must be in codes.
+// Valid then accepts IDs of any of the codes and ShardOf extracts the code.
+func WithShard(code string, codes ...string) Option {
+	if len(codes) == 0 {
+		codes = []string{code}
+	}
+	var found bool
+	for _, c := range codes {
+		if c == "" {
+			panic("rid: empty shard code")
+		}
+		if len(c) != len(codes[0]) {
+			panic(fmt.Sprintf("rid: shard codes %q differ in length", codes))
+		}
+		found = found || c == code
+	}
+	if !found {
+		panic(fmt.Sprintf("rid: shard code %q not in %q", code, codes))
+	}
+	return func(g *Generator) {
+		g.shard = code
+		g.shards = codes
+	}
+}
+
+// ShardOf returns the shard code of an ID valid for g, or an error if the code is not in the configured set
+func (g *Generator) ShardOf(id string) (string, error) {
+	if g.shards == nil {
+		return "", errors.New("rid: generator has no shard codes")
+	}
+	var k = len(g.shard)
+	if len(id) < k || !slices.Contains(g.shards, id[:k]) {
+		return "", ErrUnknownShard
+	}
+	return id[:k], nil
+}
+
+var ErrUnknownShard = errors.New("rid: unknown shard code")
+
+// alphabet without digits
+func nonDigits(alphabet []byte) []byte {
+	var chars = make([]byte, 0, len(alphabet))
+	for _, c := range alphabet {
+		if c < '0' || c > '9' {
+			chars = append(chars, c)
+		}
+	}
+	return chars
+}
+
+// Entropy returns the bits of entropy in the random part of an ID
+func (g *Generator) Entropy() float64 {
+	var n = g.length - len(g.shard)
+	if g.letterFirst {
+		return float64(n-1)*math.Log2(float64(len(g.alphabet))) + math.Log2(float64(len(g.first)))
+	}
+	return float64(n) * math.Log2(float64(len(g.alphabet)))
+}
+
+// Valid checks the length, the alphabet and the signature if configured
+func (g *Generator) Valid(id string) bool {
+	if g.signed {
+		if len(id) < g.length+g.macChars() {
+			return false
+		}
+		return g.inShard(id[:g.length]) && g.verify(id, g.length)
+	}
+	return len(id) == g.length && g.inShard(id)
+}
+
+// s starts with one of the shard codes, if any, and the rest is in the alphabet
+func (g *Generator) inShard(s string) bool {
+	if g.shards == nil {
+		return g.inAlphabet(s)
+	}
+	_, err := g.ShardOf(s)
+	return err == nil && g.inAlphabet(s[len(g.shard):])
+}
+
+func (g *Generator) inAlphabet(s string) bool {
+	if g.letterFirst && len(s) > 0 && s[0] >= '0' && s[0] <= '9' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if bytes.IndexByte(g.alphabet, s[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Reseed takes fresh seeds for the internal generator used by g, see the package Reseed.
+// It has no effect on a caller-supplied random source.
+func (g *Generator) Reseed() {
+	if g.internal != nil {
+		g.internal.reseedAll()
+	} else if g.rand == nil {
+		internalRand.reseedAll()
+	}
+}
+
+func (g *Generator) reader() io.Reader {
+	if g.rand != nil {
+		return g.rand
+	}
+	if FIPSMode() {
+		return rand.Reader
+	}
+	if g.internal != nil {
+		return g.internal
+	}
+	return internalRand
+}
+
+func checkAlphabet(alphabet string) error {
+	if len(alphabet) < 2 || len(alphabet) > 256 {
+		return fmt.Errorf("rid: alphabet length %d out of range 2..256", len(alphabet))
+	}
+	var seen [256]bool
+	for i := 0; i < len(alphabet); i++ {
+		if seen[alphabet[i]] {
+			return fmt.Errorf("rid: duplicate character %q in alphabet", alphabet[i])
+		}
+		seen[alphabet[i]] = true
+	}
+	return nil
+}
+
+// read buffers for appendAlphabet, a stack buffer would escape to the heap through io.Reader
+var readBufPool = sync.Pool{New: func() any {
+	return new([64]byte)
+}}
+
+// Appends n characters drawn uniformly from alphabet to dst.
+// Random bytes are masked to the nearest power of two and rejected when out of range, so there is no modulo bias.
+func appendAlphabet(dst []byte, r io.Reader, alphabet []byte, n int) ([]byte, error) {
+	var mask = 1<<bits.Len(uint(len(alphabet)-1)) - 1
+	var buf = readBufPool.Get().(*[64]byte)
+	defer readBufPool.Put(buf)
+	for n > 0 {
+		// expected number of bytes with a safety margin, so that usually one read is enough
+		var m = 8*(mask+1)*n/(5*len(alphabet)) + 1
+		if m > len(buf) {
+			m = len(buf)
+		}
+		if _, err := io.ReadFull(r, buf[:m]); err != nil {
+			return dst, err
+		}
+		for _, c := range buf[:m] {
+			c &= byte(mask)
+			if int(c) < len(alphabet) {
+				dst = append(dst, alphabet[c])
+				n--
+				if n == 0 {
+					break
+				}
+			}
+		}
+	}
+	return dst, nil
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Reproducible generator for golden-file tests and simulations
+///////////////////////////////////////////////////////////////////////////
+
+// DeterministicGenerator returns a generator whose IDs depend only on seed and opts.
+// The random bytes come from math/rand/v2 ChaCha8 keyed with seed as 8 little-endian bytes followed by zeros,
+// and are mapped to characters by the same rejection sampling as every Generator.
+// The sequence for a given seed is stable across releases. It is not suitable for production IDs.
+func DeterministicGenerator(seed int64, opts ...Option) *Generator {
+	var key [32]byte
+	binary.LittleEndian.PutUint64(key[:], uint64(seed))
+	return New(append([]Option{WithRand(&lockedReader{r: randv2.NewChaCha8(key)})}, opts...)...)
+}
+
+// makes a reader safe for concurrent use by several goroutines
+type lockedReader struct {
+	lk sync.Mutex
+	r  io.Reader
+}
+
+func (l *lockedReader) Read(p []byte) (int, error) {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+	return l.r.Read(p)
+}
@@ -0,0 +1,160 @@
+package rid
+
+import (
+	"io"
+	"math"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func Test_generator(t *testing.T) {
+	var g = New(WithLength(12), WithAlphabet("abc"))
+	var id = g.NewID()
+	if !regexp.MustCompile(`^[abc]{12}$`).MatchString(id) || !g.Valid(id) {
+		t.Fatalf("id not matching generator config: %s\n", id)
+	}
+	if New().Valid(id) {
+		t.Fatalf("default generator should not accept %s\n", id)
+	}
+}
+
+func Test_generatorSigned(t *testing.T) {
+	var g = New(WithSigner("secret"))
+	var id = g.NewID()
+	if !ValidRID20Signed(id, "secret") || !g.Valid(id) {
+		t.Fatalf("signed id not valid: %s\n", id)
+	}
+	if New(WithSigner("other")).Valid(id) {
+		t.Fatalf("signed id valid with wrong secret: %s\n", id)
+	}
+}
+
+func Test_macLength(t *testing.T) {
+	var g = New(WithSigner("secret"), WithMACLength(MAC256))
+	var id = g.NewID()
+	if len(id) != 20+64 || !g.Valid(id) || id[20:] != HMACn(id[:20], "secret", MAC256) {
+		t.Fatalf("invalid signed id with 256-bit MAC: %s\n", id)
+	}
+	// the 64-bit MAC is a prefix of the longer one, but a truncated ID must not pass
+	if g.Valid(id[:36]) || New(WithSigner("secret")).Valid(id) || !ValidRID20Signed(id[:36], "secret") {
+		t.Fatalf("MAC length not enforced: %s\n", id)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
+
+func Test_generatorError(t *testing.T) {
+	if _, err := New(WithRand(failingReader{})).NewIDE(); err == nil {
+		t.Fatalf("expected error from failing random source")
+	}
+}
+
+func Test_setDefault(t *testing.T) {
+	defer SetDefault(nil)
+	SetDefault(New(WithAlphabet("0123456789")))
+	var id = NewRID16()
+	if !regexp.MustCompile(`^[0-9]{16}$`).MatchString(id) {
+		t.Fatalf("NewRID16 should use the default generator: %s\n", id)
+	}
+	SetDefault(nil)
+	if id = NewRID20(); !ValidRID20(id) {
+		t.Fatalf("NewRID20 after reset not valid: %s\n", id)
+	}
+}
+
+func Test_generatorReseedPolicy(t *testing.T) {
+	var g = New(WithReseedPolicy(ReseedPolicy{Every: 1, Interval: time.Millisecond}))
+	if g.internal == nil || g.internal == internalRand {
+		t.Fatalf("generator should get its own internal generator")
+	}
+	g.Reseed()
+	if id := g.NewID(); !ValidRID20(id) {
+		t.Fatalf("invalid id: %s\n", id)
+	}
+}
+
+func Test_deterministicGenerator(t *testing.T) {
+	var a, b = DeterministicGenerator(42), DeterministicGenerator(42, WithLength(20))
+	for i := 0; i < 3; i++ {
+		if x, y := a.NewID(), b.NewID(); x != y || !ValidRID20(x) {
+			t.Fatalf("deterministic generators diverged: %s, %s\n", x, y)
+		}
+	}
+	if DeterministicGenerator(42).NewID() == DeterministicGenerator(43).NewID() {
+		t.Fatalf("different seeds should give different ids")
+	}
+}
+
+func Test_deterministicGeneratorGolden(t *testing.T) {
+	// changing these means breaking every golden file built on DeterministicGenerator
+	var g = DeterministicGenerator(1)
+	for _, want := range []string{"qm4P9pbu4LzkOSHblIul", "y0WwEKvON2IJt31fghl1", "O6rRe6eXiiwhujaMohiX"} {
+		if got := g.NewID(); got != want {
+			t.Fatalf("deterministic sequence changed: got %s, want %s\n", got, want)
+		}
+	}
+}
+
+func Test_source(t *testing.T) {
+	for _, s := range []Source{New(), NewCrypto()} {
+		if id, err := s.NewIDn(16); err != nil || !ValidRID16(id) {
+			t.Fatalf("unexpected id from source: %s, %v\n", id, err)
+		}
+	}
+}
+
+func Test_letterFirst(t *testing.T) {
+	var g = New(WithLength(4), WithAlphabet("a01b"), WithLetterFirst())
+	for i := 0; i < 50; i++ {
+		if id := g.NewID(); id[0] != 'a' && id[0] != 'b' || !g.Valid(id) {
+			t.Fatalf("id starts with a digit: %s\n", id)
+		}
+	}
+	if g.Valid("0aab") {
+		t.Fatalf("digit first should be invalid")
+	}
+	if e := New(WithLetterFirst()).Entropy(); e < 118.8 || e > 118.9 {
+		t.Fatalf("unexpected entropy: %.2f\n", e)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected panic for alphabet with less than 2 non-digits\n")
+			}
+		}()
+		New(WithLetterFirst(), WithAlphabet("0123456789a"))
+	}()
+}
+
+func Test_shard(t *testing.T) {
+	var regions = []string{"eu", "us", "ap"}
+	var eu = New(WithLength(20), WithShard("eu", regions...))
+	var us = New(WithLength(20), WithShard("us", regions...), WithSigner("secret"))
+	var id = eu.NewID()
+	if len(id) != 20 || id[:2] != "eu" || !eu.Valid(id) {
+		t.Fatalf("invalid sharded ID %s\n", id)
+	}
+	if shard, err := us.ShardOf(id); err != nil || shard != "eu" {
+		t.Fatalf("ShardOf(%s) = %s, %v\n", id, shard, err)
+	}
+	if signed := us.NewID(); signed[:2] != "us" || !us.Valid(signed) {
+		t.Fatalf("invalid signed sharded ID %s\n", signed)
+	}
+	if _, err := eu.ShardOf("xx" + id[2:]); err != ErrUnknownShard || eu.Valid("xx"+id[2:]) {
+		t.Fatalf("unknown shard accepted")
+	}
+	if math.Abs(eu.Entropy()-18*math.Log2(62)) > 1e-9 {
+		t.Fatalf("unexpected entropy %f\n", eu.Entropy())
+	}
+	defer func() {
+		if r := recover(); r != "rid: empty shard code" {
+			t.Fatalf("unexpected panic for empty shard code: %v\n", r)
+		}
+	}()
+	WithShard("")
+}
@@ -0,0 +1,142 @@
+package rid
+
+import (
+	"errors"
+	"log"
+	"math"
+	"strings"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Pronounceable IDs, consonant-vowel syllables like "kebu-rata-miso"
+///////////////////////////////////////////////////////////////////////////
+
+const (
+	Consonants = "bdfghjklmnprstvz"
+	// Vowels in lowercase only
+	vowelsLower = "aeiou"
+)
+
+var consonants = newCharset(Consonants)
+var vowels = newCharset(vowelsLower)
+
+// NewPronounceable returns the given number of consonant-vowel syllables, grouped by two with hyphens.
+// Each syllable carries log2(16*5) = 6.32 bits, see PronounceableEntropy.
+func NewPronounceable(syllables int) string {
+	var b = make([]byte, 2*syllables)
+	for i := 0; i < len(b); i += 2 {
+		b[i] = consonants.newString(1)[0]
+		b[i+1] = vowels.newString(1)[0]
+	}
+	return groupSyllables(string(b))
+}
+
+// bits of entropy of NewPronounceable(syllables), e.g. 6 syllables give 37.9 bits
+func PronounceableEntropy(syllables int) float64 {
+	return float64(syllables) * math.Log2(float64(len(Consonants)*len(vowelsLower)))
+}
+
+func ValidPronounceable(s string, syllables int) bool {
+	var plain = strings.ReplaceAll(s, "-", "")
+	if len(plain) != 2*syllables || s != groupSyllables(plain) {
+		return false
+	}
+	for i := 0; i < len(plain); i += 2 {
+		if !consonants.set[plain[i]] || !vowels.set[plain[i+1]] {
+			return false
+		}
+	}
+	return true
+}
+
+// hyphen after every 2 syllables
+func groupSyllables(plain string) string {
+	return Group(plain, 4, '-')
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Proquints, pronounceable quintuplets encoding 16 bits each, e.g. 127.0.0.1 = "lusab-babad"
+///////////////////////////////////////////////////////////////////////////
+
+const proquintVowels = "aiou"
+
+var ErrInvalidProquint = errors.New("rid: invalid proquint")
+
+// 32 random bits as two proquints
+func NewProquint32() string {
+	return EncodeProquint(randomBytes(4))
+}
+
+// 64 random bits as four proquints
+func NewProquint64() string {
+	return EncodeProquint(randomBytes(8))
+}
+
+// EncodeProquint renders big-endian 16-bit words of b as hyphen separated proquints, an odd last byte is zero padded
+func EncodeProquint(b []byte) string {
+	var out = make([]byte, 0, len(b)*3)
+	for i := 0; i < len(b); i += 2 {
+		var x = uint16(b[i]) << 8
+		if i+1 < len(b) {
+			x |= uint16(b[i+1])
+		}
+		if i > 0 {
+			out = append(out, '-')
+		}
+		out = append(out,
+			Consonants[x>>12], proquintVowels[x>>10&3], Consonants[x>>6&15], proquintVowels[x>>4&3], Consonants[x&15])
+	}
+	return string(out)
+}
+
+func DecodeProquint(s string) ([]byte, error) {
+	var words = strings.Split(s, "-")
+	var b = make([]byte, 0, 2*len(words))
+	for _, w := range words {
+		if len(w) != 5 {
+			return nil, ErrInvalidProquint
+		}
+		var x uint16
+		for i := 0; i < 5; i++ {
+			var chars, shift = Consonants, 4
+			if i%2 == 1 {
+				chars, shift = proquintVowels, 2
+			}
+			var d = strings.IndexByte(chars, w[i])
+			if d < 0 {
+				return nil, ErrInvalidProquint
+			}
+			x = x<<shift | uint16(d)
+		}
+		b = append(b, byte(x>>8), byte(x))
+	}
+	return b, nil
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Emoji IDs, for comparing short codes on device pairing screens
+///////////////////////////////////////////////////////////////////////////
+
+// 64 single code point emoji that look distinct from each other at small sizes:
+// no skin tones, no ZWJ sequences, no variation selectors, no near-duplicate faces
+const EmojiAlphabet = "🐶🐱🐭🐰🦊🐻🐼🐨🐯🦁🐮🐷🐸🐵🐔🐧" +
+	"🐢🐍🐙🦀🐳🐬🦋🐌🐝🌵🌲🍄🌻🌙🔥🌈" +
+	"🍎🍌🍇🍓🍒🍍🥕🌽🍕🍩🍪🎂🍭🏀🎈🎁" +
+	"🎸🎺🚗🚲🚀🚁⛵🏠🔑🔔💡📷📚⏰💎🎩"
+
+var emojiRunes = []rune(EmojiAlphabet)
+
+// 6 bits of entropy per emoji
+func NewEmojiID(n int) string {
+	b, err := appendRunes(make([]byte, 0, 4*n), packageReader(), emojiRunes, n)
+	if err != nil {
+		//severe error - looks like a failure of random number generator
+		log.Fatal(err)
+	}
+	return string(b)
+}
+
+// strict, exactly n emoji from EmojiAlphabet and nothing else
+func ValidEmojiID(s string, n int) bool {
+	return ValidFrom(EmojiAlphabet, s, n)
+}
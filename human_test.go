@@ -0,0 +1,50 @@
+package rid
+
+import (
+	"regexp"
+	"testing"
+)
+
+func Test_pronounceable(t *testing.T) {
+	var id = NewPronounceable(5)
+	if !regexp.MustCompile(`^[a-z]{4}-[a-z]{4}-[a-z]{2}$`).MatchString(id) || !ValidPronounceable(id, 5) {
+		t.Fatalf("invalid pronounceable id: %s\n", id)
+	}
+	if !ValidPronounceable("kebu-rata-miso", 6) || ValidPronounceable("kebu-rata-mios", 6) || ValidPronounceable("keburata-miso", 6) {
+		t.Fatalf("pronounceable validator mismatch")
+	}
+	if e := PronounceableEntropy(6); e < 37.9 || e > 38 {
+		t.Fatalf("unexpected entropy: %.2f\n", e)
+	}
+}
+
+func Test_proquint(t *testing.T) {
+	if s := EncodeProquint([]byte{127, 0, 0, 1}); s != "lusab-babad" {
+		t.Fatalf("unexpected proquint for 127.0.0.1: %s\n", s)
+	}
+	if s := EncodeProquint([]byte{63, 84, 220, 193}); s != "gutih-tugad" {
+		t.Fatalf("unexpected proquint for 63.84.220.193: %s\n", s)
+	}
+	var id = NewProquint64()
+	b, err := DecodeProquint(id)
+	if err != nil || len(b) != 8 || EncodeProquint(b) != id {
+		t.Fatalf("proquint round trip failed: %s, %v\n", id, err)
+	}
+	if _, err = DecodeProquint("lusab-babae"); err != ErrInvalidProquint {
+		t.Fatalf("expected ErrInvalidProquint, got %v\n", err)
+	}
+}
+
+func Test_emojiID(t *testing.T) {
+	if _, err := alphabetRunes(EmojiAlphabet); err != nil || len(emojiRunes) != 64 {
+		t.Fatalf("bad emoji alphabet: %d runes, %v\n", len(emojiRunes), err)
+	}
+	var id = NewEmojiID(6)
+	if !ValidEmojiID(id, 6) || ValidEmojiID(id, 5) || ValidEmojiID(id+"a", 7) {
+		t.Fatalf("emoji id validation mismatch: %s\n", id)
+	}
+	// a variation selector must not slip through
+	if ValidEmojiID("🐶️", 2) {
+		t.Fatalf("variation selector accepted")
+	}
+}
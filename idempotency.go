@@ -0,0 +1,58 @@
+package rid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Idempotency keys as accepted by APIs in the Idempotency-Key header
+///////////////////////////////////////////////////////////////////////////
+
+const (
+	IdempotencyKeyMinLength = 16
+	IdempotencyKeyMaxLength = 255
+)
+
+var ErrInvalidIdempotencyKey = errors.New("rid: invalid idempotency key")
+
+// NewIdempotencyKey returns 22 base62 chars, 131 bits, after prefix and an underscore if prefix is not empty.
+// The prefix must be valid for RegisterPrefix.
+func NewIdempotencyKey(prefix string) string {
+	if prefix == "" {
+		return NewRIDn(22)
+	}
+	if !ValidPrefix(prefix) {
+		panic(fmt.Sprintf("rid: invalid prefix %q", prefix))
+	}
+	return prefix + "_" + NewRIDn(22)
+}
+
+// ValidIdempotencyKey accepts 16 to 255 chars of letters, digits and -_.: as the server-side rule
+// for keys of any client, including UUIDs. Keys are case-sensitive.
+func ValidIdempotencyKey(key string) bool {
+	if len(key) < IdempotencyKeyMinLength || len(key) > IdempotencyKeyMaxLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		var c = key[i]
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && strings.IndexByte("-_.:", c) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// CanonicalIdempotencyKey trims surrounding whitespace and lowercases UUIDs, so that retries of one client
+// map to the same stored key, then validates
+func CanonicalIdempotencyKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if u, err := ParseUUID(key); err == nil {
+		key = u.String()
+	}
+	if !ValidIdempotencyKey(key) {
+		return "", ErrInvalidIdempotencyKey
+	}
+	return key, nil
+}
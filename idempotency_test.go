@@ -0,0 +1,26 @@
+package rid
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_idempotencyKey(t *testing.T) {
+	for _, key := range []string{NewIdempotencyKey(""), NewIdempotencyKey("checkout")} {
+		if !ValidIdempotencyKey(key) {
+			t.Fatalf("invalid idempotency key %s\n", key)
+		}
+	}
+	if key := NewIdempotencyKey("checkout"); !strings.HasPrefix(key, "checkout_") || len(key) != 31 {
+		t.Fatalf("unexpected idempotency key %s\n", key)
+	}
+	key, err := CanonicalIdempotencyKey(" 6BA7B810-9DAD-11D1-80B4-00C04FD430C8\n")
+	if err != nil || key != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+		t.Fatalf("CanonicalIdempotencyKey = %s, %v\n", key, err)
+	}
+	for _, bad := range []string{"short", "contains space 123", "key/with/slashes/1", strings.Repeat("a", 256)} {
+		if _, err = CanonicalIdempotencyKey(bad); err != ErrInvalidIdempotencyKey {
+			t.Fatalf("invalid idempotency key %q accepted\n", bad)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package rid
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_damm(t *testing.T) {
+	// the worked example of the Damm algorithm
+	if d := Damm("572"); d != '4' {
+		t.Fatalf("Damm(572) = %c, want 4\n", d)
+	}
+}
+
+func Test_documentNumbers(t *testing.T) {
+	var end uint64 = 122
+	var store = SequenceStoreFunc(func(n uint64) (uint64, error) {
+		end += n
+		return end - n + 1, nil
+	})
+	var g = NewDocumentNumbers(store, "INV", "2006", 6, true)
+	var date = time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	s, err := g.NextAt(date)
+	if err != nil || s != "INV-2025-000123-"+string(Damm("2025000123")) {
+		t.Fatalf("unexpected document number %s, %v\n", s, err)
+	}
+	d, err := g.Parse(s)
+	if err != nil || d.Prefix != "INV" || d.Date.Year() != 2025 || d.Sequence != 123 {
+		t.Fatalf("Parse(%s) = %v, %v\n", s, d, err)
+	}
+	for _, bad := range []string{"INV-2025-000132-" + s[len(s)-1:], "ORD-2025-000123-0", "INV-2025-123-" + string(Damm("2025123")), "INV-2025-000123"} {
+		if _, err = g.Parse(bad); err != ErrInvalidDocumentNumber {
+			t.Fatalf("invalid document number %s accepted\n", bad)
+		}
+	}
+	var monthly = NewDocumentNumbers(store, "ORD", "2006-01", 4, false)
+	if s, _ = monthly.NextAt(date); s != "ORD-2025-03-0124" {
+		t.Fatalf("unexpected document number %s\n", s)
+	}
+	if d, err = monthly.Parse(s); err != nil || d.Date.Month() != 3 || d.Sequence != 124 {
+		t.Fatalf("Parse(%s) = %v, %v\n", s, d, err)
+	}
+}
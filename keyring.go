@@ -0,0 +1,194 @@
+package rid
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Key rotation: signed RIDs name their key, 20 base62 characters, the key ID and the hexed HMAC of both
+///////////////////////////////////////////////////////////////////////////
+
+// maximum key ID length, key IDs are base62
+const maxKeyIDLength = 8
+
+var ErrNoCurrentKey = errors.New("rid: no current key")
+
+// KeyProvider supplies signing keys by ID, so that secrets can come from the environment, files or a secret manager
+// instead of strings passed around the application. Keyring is the in-memory implementation.
+type KeyProvider interface {
+	// the key for new signatures
+	CurrentKey() (id string, key []byte)
+	KeyByID(id string) ([]byte, bool)
+}
+
+var _ KeyProvider = (*Keyring)(nil)
+
+// WithKeyProvider signs with the current key of p and puts its ID between the random part and the MAC,
+// verification uses the key named in the ID. It implies WithSigner.
+func WithKeyProvider(p KeyProvider) Option {
+	return func(g *Generator) {
+		g.keys = p
+		g.signed = true
+	}
+}
+
+// Keyring holds the secrets of signed RIDs by key ID. New IDs are signed with the current key,
+// IDs of every key still in the ring verify, so a rotation doesn't invalidate outstanding IDs.
+type Keyring struct {
+	lk      sync.RWMutex
+	current string
+	keys    map[string][]byte
+}
+
+func NewKeyring() *Keyring {
+	return &Keyring{keys: make(map[string][]byte)}
+}
+
+// Add stores key under id, 1 to 8 base62 characters. The first key added becomes current.
+func (k *Keyring) Add(id string, key []byte) error {
+	if len(id) < 1 || len(id) > maxKeyIDLength || !b62regexp.MatchString(id) {
+		return fmt.Errorf("rid: invalid key ID %q", id)
+	}
+	k.lk.Lock()
+	defer k.lk.Unlock()
+	k.keys[id] = key
+	if k.current == "" {
+		k.current = id
+	}
+	return nil
+}
+
+// SetCurrent selects the key for new IDs
+func (k *Keyring) SetCurrent(id string) error {
+	k.lk.Lock()
+	defer k.lk.Unlock()
+	if _, ok := k.keys[id]; !ok {
+		return fmt.Errorf("rid: unknown key ID %q", id)
+	}
+	k.current = id
+	return nil
+}
+
+// Remove retires a key, IDs signed with it no longer verify. The current key can't be removed.
+func (k *Keyring) Remove(id string) error {
+	k.lk.Lock()
+	defer k.lk.Unlock()
+	if id == k.current {
+		return fmt.Errorf("rid: key ID %q is current", id)
+	}
+	delete(k.keys, id)
+	return nil
+}
+
+func (k *Keyring) CurrentKey() (string, []byte) {
+	k.lk.RLock()
+	defer k.lk.RUnlock()
+	return k.current, k.keys[k.current]
+}
+
+func (k *Keyring) KeyByID(id string) ([]byte, bool) {
+	k.lk.RLock()
+	defer k.lk.RUnlock()
+	key, ok := k.keys[id]
+	return key, ok
+}
+
+// NewRID20Signed signs with the current key, the MAC covers the key ID
+func (k *Keyring) NewRID20Signed() string {
+	return NewRID20SignedBy(k)
+}
+
+// ValidRID20Signed verifies with the key named in r
+func (k *Keyring) ValidRID20Signed(r string) bool {
+	return ValidRID20SignedBy(r, k)
+}
+
+// ValidRID20SignedAny accepts a NewRID20Signed ID, without key ID, signed with any key of the ring,
+// for the IDs issued before the move to a Keyring
+func (k *Keyring) ValidRID20SignedAny(r string) bool {
+	k.lk.RLock()
+	defer k.lk.RUnlock()
+	for _, key := range k.keys {
+		if VerifySignedRID(r, string(key)) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// NewRID20SignedBy is NewRID20Signed with the current key of p and its key ID
+func NewRID20SignedBy(p KeyProvider) string {
+	id, key := p.CurrentKey()
+	if key == nil {
+		panic(ErrNoCurrentKey)
+	}
+	r := NewRID20() + id
+	return r + HMAC(r, string(key))
+}
+
+// ValidRID20SignedBy verifies with the key of p named in r
+func ValidRID20SignedBy(r string, p KeyProvider) bool {
+	var n = len(r) - 20 - 16
+	if n < 1 || n > maxKeyIDLength || !b62regexp.MatchString(r[:20+n]) {
+		return false
+	}
+	key, ok := p.KeyByID(r[20 : 20+n])
+	return ok && hmac.Equal([]byte(r[20+n:]), []byte(HMAC(r[:20+n], string(key))))
+}
+
+// KeyringFromEnv reads keys from the environment variable name as comma-separated id:base64 pairs,
+// the first one is current, e.g. RID_KEYS=k2:c2Vjb25k,k1:Zmlyc3Q=
+func KeyringFromEnv(name string) (*Keyring, error) {
+	var s = os.Getenv(name)
+	if s == "" {
+		return nil, fmt.Errorf("rid: environment variable %s not set", name)
+	}
+	return parseKeyring(strings.Split(s, ","))
+}
+
+// KeyringFromFile reads keys from a file of id:base64 lines, the first one is current.
+// Empty lines and lines starting with # are skipped.
+func KeyringFromFile(path string) (*Keyring, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseKeyring(strings.Split(string(b), "\n"))
+}
+
+func parseKeyring(entries []string) (*Keyring, error) {
+	var k = NewKeyring()
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" || strings.HasPrefix(e, "#") {
+			continue
+		}
+		id, b64, ok := strings.Cut(e, ":")
+		key, err := base64.StdEncoding.DecodeString(b64)
+		if !ok || err != nil || len(key) == 0 {
+			return nil, fmt.Errorf("rid: invalid key entry for %q", id)
+		}
+		if err = k.Add(id, key); err != nil {
+			return nil, err
+		}
+	}
+	if k.current == "" {
+		return nil, errors.New("rid: no keys")
+	}
+	return k, nil
+}
+
+// KeyIDOf returns the key ID of a keyring-signed RID without verifying it
+func KeyIDOf(r string) string {
+	var n = len(r) - 20 - 16
+	if n < 1 || n > maxKeyIDLength {
+		return ""
+	}
+	return r[20 : 20+n]
+}
@@ -0,0 +1,77 @@
+package rid
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_keyring(t *testing.T) {
+	var k = NewKeyring()
+	if err := k.Add("k1", []byte("first secret")); err != nil {
+		t.Fatal(err)
+	}
+	var old = k.NewRID20Signed()
+	if len(old) != 38 || KeyIDOf(old) != "k1" || !k.ValidRID20Signed(old) {
+		t.Fatalf("invalid keyring-signed RID %s\n", old)
+	}
+	// rotation: new IDs use k2, old ones still verify until k1 is removed
+	k.Add("k2", []byte("second secret"))
+	if err := k.SetCurrent("k2"); err != nil {
+		t.Fatal(err)
+	}
+	var id = k.NewRID20Signed()
+	if KeyIDOf(id) != "k2" || !k.ValidRID20Signed(id) || !k.ValidRID20Signed(old) {
+		t.Fatalf("rotation broke verification: %s %s\n", old, id)
+	}
+	// the MAC covers the key ID
+	if k.ValidRID20Signed(id[:20] + "k1" + id[22:]) {
+		t.Fatalf("swapped key ID accepted")
+	}
+	if err := k.Remove("k2"); err == nil {
+		t.Fatalf("current key removed")
+	}
+	k.Remove("k1")
+	if k.ValidRID20Signed(old) {
+		t.Fatalf("ID of removed key verified")
+	}
+	if err := k.Add("bad-id", nil); err == nil {
+		t.Fatalf("invalid key ID accepted")
+	}
+}
+
+func Test_keyProvider(t *testing.T) {
+	t.Setenv("TEST_RID_KEYS", "k2:c2Vjb25k, k1:Zmlyc3Q=")
+	k, err := KeyringFromEnv("TEST_RID_KEYS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, key := k.CurrentKey(); id != "k2" || string(key) != "second" {
+		t.Fatalf("CurrentKey = %s, %s\n", id, key)
+	}
+	var path = filepath.Join(t.TempDir(), "keys")
+	os.WriteFile(path, []byte("# rotated 2026-10\nk2:c2Vjb25k\n\nk1:Zmlyc3Q=\n"), 0600)
+	fromFile, err := KeyringFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var id = NewRID20SignedBy(k)
+	if !ValidRID20SignedBy(id, fromFile) {
+		t.Fatalf("keyrings from env and file disagree on %s\n", id)
+	}
+	var g = New(WithLength(12), WithKeyProvider(k), WithBase62MAC(), WithMAC(HMACSHA3_256))
+	id = g.NewID()
+	if id[12:14] != "k2" || !g.Valid(id) || !New(WithLength(12), WithKeyProvider(fromFile), WithBase62MAC(), WithMAC(HMACSHA3_256)).Valid(id) {
+		t.Fatalf("invalid provider-signed ID %s\n", id)
+	}
+	k.SetCurrent("k1")
+	if !g.Valid(id) || g.Valid(id[:12]+"k1"+id[14:]) || g.Valid(id[:12]+"kx"+id[14:]) {
+		t.Fatalf("key ID of provider-signed ID not enforced: %s\n", id)
+	}
+	if _, err = New(WithKeyProvider(NewKeyring())).NewIDE(); err != ErrNoCurrentKey {
+		t.Fatalf("signed with an empty keyring, %v\n", err)
+	}
+	if _, err = KeyringFromEnv("TEST_RID_MISSING"); err == nil {
+		t.Fatalf("missing environment variable accepted")
+	}
+}
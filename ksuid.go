@@ -0,0 +1,67 @@
+package rid
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"time"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// KSUID, Segment's K-sortable ID: 32-bit second timestamp and 128 random bits in 27 chars of base62
+///////////////////////////////////////////////////////////////////////////
+
+type KSUID [20]byte
+
+// KSUID digit order, unlike RIDs digits come first
+const KSUIDAlphabet = Base62SortableAlphabet
+
+// KSUID timestamps count seconds from 2014-05-13 16:53:20 UTC
+const KSUIDEpoch = 1400000000
+
+var ErrInvalidKSUID = errors.New("rid: invalid KSUID")
+
+func NewKSUID() string {
+	return NewKSUIDAt(time.Now()).String()
+}
+
+func NewKSUIDAt(t time.Time) KSUID {
+	var k KSUID
+	binary.BigEndian.PutUint32(k[:4], uint32(t.Unix()-KSUIDEpoch))
+	copy(k[4:], randomBytes(16))
+	return k
+}
+
+// Time returns the embedded timestamp with second precision
+func (k KSUID) Time() time.Time {
+	return time.Unix(int64(binary.BigEndian.Uint32(k[:4]))+KSUIDEpoch, 0)
+}
+
+// the 16 random bytes
+func (k KSUID) Payload() []byte {
+	return k[4:]
+}
+
+// 27 chars of base62 left padded with '0'
+func (k KSUID) String() string {
+	s, _ := intToDigits(new(big.Int).SetBytes(k[:]), KSUIDAlphabet, 27)
+	return s
+}
+
+func ParseKSUID(s string) (KSUID, error) {
+	var k KSUID
+	if len(s) != 27 {
+		return k, ErrInvalidKSUID
+	}
+	x, ok := digitsToInt(s, KSUIDAlphabet)
+	if !ok || x.BitLen() > 160 {
+		return k, ErrInvalidKSUID
+	}
+	x.FillBytes(k[:])
+	return k, nil
+}
+
+func ValidKSUID(s string) bool {
+	_, err := ParseKSUID(s)
+	return err == nil
+}
@@ -0,0 +1,24 @@
+package rid
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_ksuid(t *testing.T) {
+	// example from the segmentio/ksuid README
+	k, err := ParseKSUID("0ujtsYcgvSTl8PAuAdqWYSMnLOv")
+	if err != nil || k.Time().Unix() != 1507608047 || strings.ToUpper(hex.EncodeToString(k.Payload())) != "B5A1CD34B5F99D1154FB6853345C9735" {
+		t.Fatalf("unexpected KSUID components: %v, %x, %v\n", k.Time(), k.Payload(), err)
+	}
+	var s = NewKSUID()
+	k, err = ParseKSUID(s)
+	if err != nil || k.String() != s || time.Since(k.Time()) > time.Minute {
+		t.Fatalf("KSUID round trip failed: %s, %v\n", s, err)
+	}
+	if ValidKSUID("aWgEPTl1tmebfsQzFP4bxwgy80W") || ValidKSUID("0ujtsYcgvSTl8PAuAdqWYSMnLO") {
+		t.Fatalf("KSUID above 160 bits or too short accepted")
+	}
+}
@@ -0,0 +1,54 @@
+package rid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// License keys, 25 chars of Crockford base32 in 5 groups: XXXXX-XXXXX-XXXXX-XXXXX-XXXXX.
+// 15 bytes of 16 data bits, 64 random bits and 40 bits of HMAC or more random, then a Luhn mod 32 check char.
+///////////////////////////////////////////////////////////////////////////
+
+var ErrInvalidLicenseKey = errors.New("rid: invalid license key")
+
+var ErrLicenseSignature = errors.New("rid: license key signature mismatch")
+
+// NewLicenseKey embeds data, e.g. product<<8|edition. With a secret the last 40 bits are the HMAC of the rest,
+// which ParseLicenseKey verifies offline. Anyone who extracts the secret from a shipped binary can mint keys,
+// so check keys online where that matters.
+func NewLicenseKey(data uint16, secret string) string {
+	var b [15]byte
+	b[0], b[1] = byte(data>>8), byte(data)
+	copy(b[2:], randomBytes(13))
+	if secret != "" {
+		copy(b[10:], licenseMAC(b[:10], secret))
+	}
+	s, _ := intToDigits(new(big.Int).SetBytes(b[:]), CrockfordAlphabet, 24)
+	return Group(s+string(luhnModN(s, CrockfordAlphabet)), 5, '-')
+}
+
+// ParseLicenseKey returns the embedded data. It accepts lowercase, missing or extra hyphens and the Crockford
+// aliases I, L -> 1 and O -> 0. With a secret the signature is verified in constant time.
+func ParseLicenseKey(key string, secret string) (uint16, error) {
+	n, err := NormalizeCrockford(key)
+	if err != nil || len(n) != 25 || !crockford.valid(n) || luhnModN(n[:24], CrockfordAlphabet) != n[24] {
+		return 0, ErrInvalidLicenseKey
+	}
+	var b [15]byte
+	x, _ := digitsToInt(n[:24], CrockfordAlphabet)
+	x.FillBytes(b[:])
+	if secret != "" && !hmac.Equal(b[10:], licenseMAC(b[:10], secret)) {
+		return 0, ErrLicenseSignature
+	}
+	return uint16(b[0])<<8 | uint16(b[1]), nil
+}
+
+func licenseMAC(payload []byte, secret string) []byte {
+	var mac = hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("rid license key"))
+	mac.Write(payload)
+	return mac.Sum(nil)[:5]
+}
@@ -0,0 +1,31 @@
+package rid
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var licenseRegexp = regexp.MustCompile(`^[0-9A-Z]{5}(-[0-9A-Z]{5}){4}$`)
+
+func Test_licenseKey(t *testing.T) {
+	var key = NewLicenseKey(3<<8|2, "secret")
+	if !licenseRegexp.MatchString(key) {
+		t.Fatalf("unexpected license key format %s\n", key)
+	}
+	if data, err := ParseLicenseKey(strings.ToLower(strings.ReplaceAll(key, "-", "")), "secret"); err != nil || data != 3<<8|2 {
+		t.Fatalf("ParseLicenseKey(%s) = %d, %v\n", key, data, err)
+	}
+	if _, err := ParseLicenseKey(key, "other"); err != ErrLicenseSignature {
+		t.Fatalf("wrong secret not detected: %v\n", err)
+	}
+	// a typo is caught by the check char before the signature
+	var b = []byte(key)
+	b[3] = CrockfordAlphabet[(strings.IndexByte(CrockfordAlphabet, b[3])+1)%32]
+	if _, err := ParseLicenseKey(string(b), "secret"); err != ErrInvalidLicenseKey {
+		t.Fatalf("typo not detected in %s: %v\n", b, err)
+	}
+	if data, err := ParseLicenseKey(NewLicenseKey(7, ""), ""); err != nil || data != 7 {
+		t.Fatalf("unsigned license key = %d, %v\n", data, err)
+	}
+}
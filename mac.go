@@ -0,0 +1,186 @@
+package rid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"math/big"
+	"strings"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// MAC of signed IDs: algorithm, truncation and rendering
+///////////////////////////////////////////////////////////////////////////
+
+// MACAlgorithm is a keyed hash for WithMAC. Signed IDs carry its Code in front of the MAC,
+// so a generator verifies IDs of each algorithm it accepts, which allows migrating between them.
+type MACAlgorithm struct {
+	// a base62 character, unique among the algorithms of a generator
+	Code byte
+	Name string
+	// returns the keyed hash, it must produce at least 32 bytes
+	New func(key []byte) hash.Hash
+	// FIPS-approved, in FIPS mode other algorithms neither sign nor verify
+	Approved bool
+}
+
+func hmacWith(h func() hash.Hash) func(key []byte) hash.Hash {
+	return func(key []byte) hash.Hash {
+		return hmac.New(h, key)
+	}
+}
+
+var (
+	HMACSHA256     = &MACAlgorithm{Code: 'A', Name: "HMAC-SHA256", New: hmacWith(sha256.New), Approved: true}
+	HMACSHA512_256 = &MACAlgorithm{Code: 'B', Name: "HMAC-SHA512/256", New: hmacWith(sha512.New512_256), Approved: true}
+	HMACSHA3_256   = &MACAlgorithm{Code: 'C', Name: "HMAC-SHA3-256", New: hmacWith(func() hash.Hash { return sha3.New256() }), Approved: true}
+)
+
+// WithMAC signs with alg instead of plain HMAC-SHA256 and puts its code in front of the MAC.
+// Valid accepts IDs of alg and of the accepted algorithms only, e.g. of the previous one during a migration.
+// Algorithms outside the standard library work too, e.g. keyed BLAKE2b:
+//
+//	rid.WithMAC(&rid.MACAlgorithm{Code: 'D', Name: "BLAKE2b-256", New: func(key []byte) hash.Hash {
+//		h, _ := blake2b.New256(key)
+//		return h
+//	}}, rid.HMACSHA256)
+//
+// BLAKE2b is not FIPS-approved, so Approved stays false and the algorithm is refused in FIPS mode.
+// WithMAC(nil) signs with plain HMAC-SHA256 without code.
+func WithMAC(alg *MACAlgorithm, accepted ...*MACAlgorithm) Option {
+	if alg == nil && len(accepted) > 0 {
+		panic("rid: accepted MAC algorithms without a signing algorithm")
+	}
+	var codes = make(map[byte]bool)
+	for _, a := range append([]*MACAlgorithm{alg}, accepted...) {
+		if a == nil && alg != nil {
+			panic("rid: nil accepted MAC algorithm")
+		}
+		if a == nil {
+			continue
+		}
+		if strings.IndexByte(Base62Alphabet, a.Code) < 0 {
+			panic(fmt.Sprintf("rid: MAC algorithm code %q is not base62", a.Code))
+		}
+		if codes[a.Code] {
+			panic(fmt.Sprintf("rid: MAC algorithm code %q used twice", a.Code))
+		}
+		codes[a.Code] = true
+	}
+	return func(g *Generator) {
+		g.mac = alg
+		g.accepted = accepted
+	}
+}
+
+// the algorithm of code among the generator's own and the accepted ones, or nil
+func (g *Generator) acceptedMAC(code byte) *MACAlgorithm {
+	if g.mac.Code == code {
+		return g.mac
+	}
+	for _, alg := range g.accepted {
+		if alg.Code == code {
+			return alg
+		}
+	}
+	return nil
+}
+
+// WithMACLength truncates the HMAC of WithSigner to n bytes instead of MAC64, e.g. MAC128 or MAC256
+func WithMACLength(n int) Option {
+	if n < 4 || n > sha256.Size {
+		panic(fmt.Sprintf("rid: MAC length %d out of range 4..%d", n, sha256.Size))
+	}
+	return func(g *Generator) {
+		g.macLen = n
+	}
+}
+
+// WithBase62MAC renders the MAC of WithSigner in base62 instead of hex, so signed IDs stay in the alphabet [A-Za-z0-9]
+func WithBase62MAC() Option {
+	return func(g *Generator) {
+		g.macB62 = true
+	}
+}
+
+// sign returns what follows id: the key ID if a KeyProvider is set, the algorithm code if WithMAC is set, and the MAC.
+// It fails with ErrNoCurrentKey if the KeyProvider has no current key
+// and with ErrNotFIPSApproved for an algorithm not approved in FIPS mode.
+func (g *Generator) sign(id string) (string, error) {
+	if g.mac != nil && !g.mac.Approved && FIPSMode() {
+		return "", ErrNotFIPSApproved
+	}
+	var kid, key = "", []byte(g.secret)
+	if g.keys != nil {
+		if kid, key = g.keys.CurrentKey(); kid == "" || key == nil {
+			return "", ErrNoCurrentKey
+		}
+		id += kid
+	}
+	if g.mac == nil {
+		return kid + g.render(HMACSHA256, key, id), nil
+	}
+	return kid + string(g.mac.Code) + g.render(g.mac, key, id), nil
+}
+
+// verify checks sig of id as produced by sign, n is the length of the random part
+func (g *Generator) verify(id string, n int) bool {
+	var key = []byte(g.secret)
+	var sig = id[len(id)-g.macChars():]
+	id = id[:len(id)-g.macChars()]
+	if g.keys != nil {
+		var kid = id[n:]
+		if len(kid) < 1 || len(kid) > maxKeyIDLength {
+			return false
+		}
+		var ok bool
+		if key, ok = g.keys.KeyByID(kid); !ok {
+			return false
+		}
+	} else if len(id) != n {
+		return false
+	}
+	var alg = HMACSHA256
+	if g.mac != nil {
+		if alg = g.acceptedMAC(sig[0]); alg == nil || !alg.Approved && FIPSMode() {
+			return false
+		}
+		sig = sig[1:]
+	}
+	return hmac.Equal([]byte(sig), []byte(g.render(alg, key, id)))
+}
+
+// the MAC of id truncated and rendered as configured
+func (g *Generator) render(alg *MACAlgorithm, key []byte, id string) string {
+	var h = alg.New(key)
+	h.Write([]byte(id))
+	var sum = h.Sum(nil)[:g.macBytes()]
+	if g.macB62 {
+		s, _ := intToDigits(new(big.Int).SetBytes(sum), Base62Alphabet, base62Width(len(sum)))
+		return s
+	}
+	return hex.EncodeToString(sum)
+}
+
+// length of the rendered MAC
+func (g *Generator) macChars() int {
+	var n = 2 * g.macBytes()
+	if g.macB62 {
+		n = base62Width(g.macBytes())
+	}
+	if g.mac != nil {
+		n++
+	}
+	return n
+}
+
+func (g *Generator) macBytes() int {
+	if g.macLen == 0 {
+		return MAC64
+	}
+	return g.macLen
+}
@@ -0,0 +1,38 @@
+package rid
+
+import (
+	"testing"
+)
+
+func Test_macAlgorithms(t *testing.T) {
+	var sha3 = New(WithSigner("secret"), WithMAC(HMACSHA3_256), WithBase62MAC())
+	var id = sha3.NewID()
+	if len(id) != 20+1+11 || id[20] != 'C' || !b62regexp.MatchString(id) || !sha3.Valid(id) {
+		t.Fatalf("invalid HMAC-SHA3-256 signed ID %s\n", id)
+	}
+	// a generator moved to another algorithm verifies IDs signed before only while it accepts the old one
+	var sha512 = New(WithSigner("secret"), WithMAC(HMACSHA512_256, HMACSHA3_256), WithBase62MAC())
+	var newer = sha512.NewID()
+	if newer[20] != 'B' || !sha512.Valid(newer) || !sha512.Valid(id) {
+		t.Fatalf("IDs of accepted algorithms not verified: %s %s\n", id, newer)
+	}
+	if sha512.Valid(id[:20]+"Z"+id[21:]) || sha512.Valid(id[:20]+"B"+id[21:]) {
+		t.Fatalf("unknown or wrong algorithm code accepted")
+	}
+	if migrated := New(WithSigner("secret"), WithMAC(HMACSHA512_256), WithBase62MAC()); migrated.Valid(id) || !migrated.Valid(newer) {
+		t.Fatalf("algorithm accepted that is not configured: %s\n", id)
+	}
+	// plain HMAC-SHA256 IDs carry no code and stay compatible with NewRID20Signed
+	if plain := New(WithSigner("secret"), WithMAC(nil)).NewID(); !ValidRID20Signed(plain, "secret") {
+		t.Fatalf("plain signed ID changed: %s\n", plain)
+	}
+}
+
+func Test_duplicateMACCode(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("duplicate algorithm code accepted")
+		}
+	}()
+	WithMAC(HMACSHA256, &MACAlgorithm{Code: 'A', Name: "duplicate", New: HMACSHA256.New})
+}
@@ -0,0 +1,112 @@
+package rid
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// BIP39 mnemonics of RIDs, for writing an ID down and recovering it later
+///////////////////////////////////////////////////////////////////////////
+
+var (
+	ErrBIP39Wordlist = errors.New("rid: BIP39 wordlist must have 2048 words")
+	ErrMnemonic      = errors.New("rid: invalid mnemonic")
+)
+
+// https://github.com/bitcoin/bips/blob/master/bip-0039/english.txt
+//
+//go:embed wordlists/bip39_english.txt
+var bip39EnglishFile string
+
+// BIP39English is the English BIP39 wordlist
+var BIP39English = Wordlist(parseWordlist(bip39EnglishFile))
+
+var errMnemonicTooLong = errors.New("rid: RID too long for a BIP39 mnemonic")
+
+// EncodeMnemonic renders the value of a base62 RID as BIP39 words from w.
+// The value is taken as BIP39 entropy of the smallest multiple of 32 bits that holds any n-char RID
+// (96 bits, 9 words for RID16; 128 bits, 12 words for RID20), followed by the BIP39 checksum.
+// w must be a 2048 word list, usually BIP39English.
+func EncodeMnemonic(w Wordlist, id string) (string, error) {
+	if len(w) != 2048 {
+		return "", ErrBIP39Wordlist
+	}
+	x, ok := b62ToInt(id)
+	if !ok || len(id) == 0 {
+		return "", ErrInvalidRID
+	}
+	var entBits = mnemonicEntropyBits(len(id))
+	if entBits > 256 {
+		return "", errMnemonicTooLong
+	}
+	return strings.Join(mnemonicWords(w, x.FillBytes(make([]byte, entBits/8))), " "), nil
+}
+
+// BIP39 words of entropy, a multiple of 4 bytes up to 32 bytes
+func mnemonicWords(w Wordlist, entropy []byte) []string {
+	var cs = uint(len(entropy) / 4)
+	var h = sha256.Sum256(entropy)
+	var all = new(big.Int).Lsh(new(big.Int).SetBytes(entropy), cs)
+	all.Or(all, big.NewInt(int64(h[0]>>(8-cs))))
+	var words = make([]string, (8*len(entropy)+int(cs))/11)
+	var mask = big.NewInt(2047)
+	for i := len(words) - 1; i >= 0; i-- {
+		words[i] = w[new(big.Int).And(all, mask).Int64()]
+		all.Rsh(all, 11)
+	}
+	return words
+}
+
+// DecodeMnemonic is the inverse of EncodeMnemonic for an n-char RID, the checksum is verified
+func DecodeMnemonic(w Wordlist, mnemonic string, n int) (string, error) {
+	if len(w) != 2048 {
+		return "", ErrBIP39Wordlist
+	}
+	var index = make(map[string]int64, len(w))
+	for i, word := range w {
+		index[word] = int64(i)
+	}
+	if n < 1 {
+		return "", ErrMnemonic
+	}
+	var entBits = mnemonicEntropyBits(n)
+	if entBits > 256 {
+		return "", errMnemonicTooLong
+	}
+	var cs = uint(entBits / 32)
+	var words = strings.Fields(mnemonic)
+	if len(words) != (entBits+int(cs))/11 {
+		return "", ErrMnemonic
+	}
+	var all = new(big.Int)
+	for _, word := range words {
+		i, ok := index[word]
+		if !ok {
+			return "", ErrMnemonic
+		}
+		all.Lsh(all, 11)
+		all.Or(all, big.NewInt(i))
+	}
+	var checksum = byte(new(big.Int).And(all, big.NewInt(1<<cs-1)).Int64())
+	var x = all.Rsh(all, cs)
+	var h = sha256.Sum256(x.FillBytes(make([]byte, entBits/8)))
+	if h[0]>>(8-cs) != checksum {
+		return "", ErrMnemonic
+	}
+	id, ok := intToB62(x, n)
+	if !ok {
+		return "", ErrMnemonic
+	}
+	return id, nil
+}
+
+// smallest multiple of 32 bits holding 62^n - 1
+func mnemonicEntropyBits(n int) int {
+	var max = new(big.Int).Exp(big62, big.NewInt(int64(n)), nil)
+	var bits = max.Sub(max, big.NewInt(1)).BitLen()
+	return (bits + 31) / 32 * 32
+}
@@ -0,0 +1,73 @@
+package rid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func Test_bip39English(t *testing.T) {
+	// published checksum of english.txt
+	if h := sha256.Sum256([]byte(bip39EnglishFile)); hex.EncodeToString(h[:]) != "2f5eed53a4727b4bf8880d8f3f199efc90e58503646d9ff8eff3a2ed3b24dbda" {
+		t.Fatalf("embedded BIP39 list differs from english.txt\n")
+	}
+	if len(BIP39English) != 2048 || BIP39English[0] != "abandon" || BIP39English[2047] != "zoo" {
+		t.Fatalf("bad BIP39 list, %d words\n", len(BIP39English))
+	}
+	// vectors of the BIP39 reference implementation
+	for _, c := range []struct {
+		entropy  string
+		mnemonic string
+	}{
+		{"00000000000000000000000000000000", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"},
+		{"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f", "legal winner thank year wave sausage worth useful legal winner thank yellow"},
+		{"80808080808080808080808080808080", "letter advice cage absurd amount doctor acoustic avoid letter advice cage above"},
+		{"ffffffffffffffffffffffffffffffff", "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong"},
+		{"0000000000000000000000000000000000000000000000000000000000000000", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"},
+	} {
+		entropy, _ := hex.DecodeString(c.entropy)
+		if m := strings.Join(mnemonicWords(BIP39English, entropy), " "); m != c.mnemonic {
+			t.Fatalf("mnemonic of %s = %s, want %s\n", c.entropy, m, c.mnemonic)
+		}
+	}
+}
+
+func Test_mnemonic(t *testing.T) {
+	var w = BIP39English
+	for _, id := range []string{NewRID16(), NewRID20(), "AAAAAAAAAAAAAAAAAAAA", "99999999999999999999"} {
+		m, err := EncodeMnemonic(w, id)
+		if err != nil {
+			t.Fatalf("encode %s: %v\n", id, err)
+		}
+		var words = len(strings.Fields(m))
+		if (len(id) == 16 && words != 9) || (len(id) == 20 && words != 12) {
+			t.Fatalf("unexpected number of words for %s: %d\n", id, words)
+		}
+		back, err := DecodeMnemonic(w, m, len(id))
+		if err != nil || back != id {
+			t.Fatalf("round trip failed: %s -> %s -> %s, %v\n", id, m, back, err)
+		}
+	}
+	// RID20 "AAAA..." is the all-zero 128-bit entropy
+	m, _ := EncodeMnemonic(w, "AAAAAAAAAAAAAAAAAAAA")
+	if m != "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about" {
+		t.Fatalf("unexpected mnemonic: %s\n", m)
+	}
+	var words = strings.Fields(m)
+	words[0] = "ability"
+	if _, err := DecodeMnemonic(w, strings.Join(words, " "), 20); err != ErrMnemonic {
+		t.Fatalf("expected checksum failure, got %v\n", err)
+	}
+	if _, err := EncodeMnemonic(w[:100], "abc"); err != ErrBIP39Wordlist {
+		t.Fatalf("expected ErrBIP39Wordlist, got %v\n", err)
+	}
+	// more than 256 bits of entropy would shift the checksum out of its byte
+	var long = strings.Repeat("abandon ", 99) + "abandon"
+	if _, err := DecodeMnemonic(w, long, 60); err == nil || err == ErrBIP39Wordlist {
+		t.Fatalf("DecodeMnemonic accepted a 60-char RID, %v\n", err)
+	}
+	if _, err := DecodeMnemonic(w, m, 0); err != ErrMnemonic {
+		t.Fatalf("DecodeMnemonic accepted length 0, %v\n", err)
+	}
+}
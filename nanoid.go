@@ -0,0 +1,26 @@
+package rid
+
+///////////////////////////////////////////////////////////////////////////
+// NanoID, 21 chars of the URL-safe base64 alphabet, compatible with the JavaScript nanoid package
+///////////////////////////////////////////////////////////////////////////
+
+// nanoid's urlAlphabet, A-Za-z0-9_- in the order nanoid uses
+const NanoIDAlphabet = "useandom-26T198340PX75pxJACKVERYMINDBUSHWOLF_GQZbfghjklqvwyzrict"
+
+// default size of nanoid, 126 bits of entropy
+const NanoIDSize = 21
+
+var nanoid = newCharset(NanoIDAlphabet)
+
+func NewNanoID() string {
+	return nanoid.newString(NanoIDSize)
+}
+
+// like nanoid's customAlphabet, same sampling by masked random bytes with rejection
+func NewNanoIDCustom(alphabet string, size int) (string, error) {
+	return NewStringFrom(alphabet, size)
+}
+
+func ValidNanoID(s string) bool {
+	return len(s) == NanoIDSize && nanoid.valid(s)
+}
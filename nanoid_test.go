@@ -0,0 +1,22 @@
+package rid
+
+import (
+	"testing"
+)
+
+func Test_nanoid(t *testing.T) {
+	var id = NewNanoID()
+	if !ValidNanoID(id) || len(id) != 21 {
+		t.Fatalf("invalid NanoID %s\n", id)
+	}
+	if ValidNanoID(id[1:]) || ValidNanoID(id[1:]+"+") {
+		t.Fatalf("invalid NanoIDs accepted")
+	}
+	id, err := NewNanoIDCustom("1234567890abcdef", 10)
+	if err != nil || !ValidFrom("1234567890abcdef", id, 10) {
+		t.Fatalf("invalid custom NanoID %s, %v\n", id, err)
+	}
+	if _, err = NewNanoIDCustom("aa", 10); err == nil {
+		t.Fatalf("duplicate characters should be rejected")
+	}
+}
@@ -0,0 +1,76 @@
+package rid
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"os"
+	"strconv"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Node IDs for the worker component of Snowflake, TSID and composite IDs
+///////////////////////////////////////////////////////////////////////////
+
+// NodeIDEnv holds an explicit node ID, the only source that guarantees distinct IDs across replicas
+const NodeIDEnv = "RID_NODE_ID"
+
+var ErrNoNodeID = errors.New("rid: no node ID source available")
+
+// DetectNodeID returns a node ID below 1<<bits from the first available source:
+// the RID_NODE_ID environment variable, the first hardware MAC address, the host name.
+// Hashed sources of different machines collide with probability 2^-bits per pair,
+// so with many replicas set RID_NODE_ID, e.g. from the StatefulSet ordinal.
+//
+//	cfg.Worker, err = rid.DetectNodeID(cfg.WorkerBits)
+func DetectNodeID(bits uint) (int64, error) {
+	if id, ok, err := NodeIDFromEnv(bits); ok || err != nil {
+		return id, err
+	}
+	if id, err := NodeIDFromMAC(bits); err == nil {
+		return id, nil
+	}
+	return NodeIDFromHostname(bits)
+}
+
+// NodeIDFromEnv parses RID_NODE_ID, ok is false if it is not set
+func NodeIDFromEnv(bits uint) (id int64, ok bool, err error) {
+	var s = os.Getenv(NodeIDEnv)
+	if s == "" {
+		return 0, false, nil
+	}
+	id, err = strconv.ParseInt(s, 10, 64)
+	if err != nil || id < 0 || id >= 1<<bits {
+		return 0, true, fmt.Errorf("rid: %s=%q is not a node ID below %d", NodeIDEnv, s, int64(1)<<bits)
+	}
+	return id, true, nil
+}
+
+// NodeIDFromMAC hashes the first non-loopback interface with a hardware address
+func NodeIDFromMAC(bits uint) (int64, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return 0, err
+	}
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback == 0 && len(iface.HardwareAddr) > 0 {
+			return hashNodeID(iface.HardwareAddr, bits), nil
+		}
+	}
+	return 0, ErrNoNodeID
+}
+
+func NodeIDFromHostname(bits uint) (int64, error) {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return 0, ErrNoNodeID
+	}
+	return hashNodeID([]byte(hostname), bits), nil
+}
+
+func hashNodeID(b []byte, bits uint) int64 {
+	var h = fnv.New64a()
+	h.Write(b)
+	return int64(h.Sum64() & (1<<bits - 1))
+}
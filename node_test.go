@@ -0,0 +1,27 @@
+package rid
+
+import (
+	"testing"
+)
+
+func Test_nodeID(t *testing.T) {
+	t.Setenv(NodeIDEnv, "")
+	id, err := DetectNodeID(10)
+	if err != nil || id < 0 || id >= 1<<10 {
+		t.Fatalf("DetectNodeID = %d, %v\n", id, err)
+	}
+	if again, _ := DetectNodeID(10); again != id {
+		t.Fatalf("node ID not stable: %d != %d\n", again, id)
+	}
+	t.Setenv(NodeIDEnv, "37")
+	if id, err = DetectNodeID(10); err != nil || id != 37 {
+		t.Fatalf("DetectNodeID = %d, %v, want 37\n", id, err)
+	}
+	t.Setenv(NodeIDEnv, "1024")
+	if _, err = DetectNodeID(10); err == nil {
+		t.Fatalf("node ID out of range accepted")
+	}
+	if hashNodeID([]byte("web-0"), 10) == hashNodeID([]byte("web-1"), 10) {
+		t.Fatalf("hash collision of neighbouring host names")
+	}
+}
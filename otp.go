@@ -0,0 +1,35 @@
+package rid
+
+import (
+	"crypto/subtle"
+	"log"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Numeric one-time passwords and PINs
+///////////////////////////////////////////////////////////////////////////
+
+var decimalDigits = []byte("0123456789")
+
+// NewOTP returns exactly n uniform decimal digits from crypto/rand, leading zeros included
+func NewOTP(n int) string {
+	otp, err := NewOTPE(n)
+	if err != nil {
+		//severe error - looks like a failure of system random number generator
+		log.Fatal(err)
+	}
+	return otp
+}
+
+func NewOTPE(n int) (string, error) {
+	b, err := appendAlphabet(make([]byte, 0, n), cryptoReader(), decimalDigits, n)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// VerifyOTP compares in constant time, only the length may leak through timing
+func VerifyOTP(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,25 @@
+package rid
+
+import (
+	"testing"
+)
+
+func Test_otp(t *testing.T) {
+	var counts [10]int
+	for i := 0; i < 10000; i++ {
+		var otp = NewOTP(6)
+		if len(otp) != 6 || !VerifyOTP(otp, otp) {
+			t.Fatalf("invalid OTP %s\n", otp)
+		}
+		counts[otp[0]-'0']++
+	}
+	// leading zeros are kept, each first digit about 1000 times
+	for d, c := range counts {
+		if c < 800 || c > 1200 {
+			t.Fatalf("first digit %d occurs %d times of 10000\n", d, c)
+		}
+	}
+	if VerifyOTP("012345", "12345") || VerifyOTP("012345", "012346") {
+		t.Fatalf("different OTPs verified")
+	}
+}
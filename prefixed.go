@@ -0,0 +1,104 @@
+package rid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Stripe-style prefixed IDs, e.g. cus_k9GxT2bQ8mZr4LwP1sVy7NcA, with a registry of prefixes
+///////////////////////////////////////////////////////////////////////////
+
+// PrefixType describes a registered prefix
+type PrefixType struct {
+	Prefix string
+	Entity string
+	// length of the base62 part after the underscore
+	Length int
+}
+
+// length of the random part for unregistered prefixes, 142.9 bits of entropy
+const DefaultPrefixedLength = 24
+
+var ErrUnknownPrefix = errors.New("rid: unknown ID prefix")
+
+var ErrInvalidPrefixed = errors.New("rid: invalid prefixed ID")
+
+var prefixRegistry struct {
+	lk sync.RWMutex
+	m  map[string]PrefixType
+}
+
+// RegisterPrefix is meant to be called from init. Prefixes are lowercase letters, digits and inner underscores.
+func RegisterPrefix(prefix, entity string, length int) error {
+	if !ValidPrefix(prefix) {
+		return fmt.Errorf("rid: invalid prefix %q", prefix)
+	}
+	if length < 1 {
+		return fmt.Errorf("rid: invalid length %d", length)
+	}
+	prefixRegistry.lk.Lock()
+	defer prefixRegistry.lk.Unlock()
+	if _, ok := prefixRegistry.m[prefix]; ok {
+		return fmt.Errorf("rid: prefix %q already registered", prefix)
+	}
+	if prefixRegistry.m == nil {
+		prefixRegistry.m = make(map[string]PrefixType)
+	}
+	prefixRegistry.m[prefix] = PrefixType{Prefix: prefix, Entity: entity, Length: length}
+	return nil
+}
+
+// LookupPrefix returns the registration of prefix
+func LookupPrefix(prefix string) (PrefixType, bool) {
+	prefixRegistry.lk.RLock()
+	defer prefixRegistry.lk.RUnlock()
+	t, ok := prefixRegistry.m[prefix]
+	return t, ok
+}
+
+// NewPrefixedID uses the registered length of prefix, or DefaultPrefixedLength. It panics on invalid prefix.
+func NewPrefixedID(prefix string) string {
+	if !ValidPrefix(prefix) {
+		panic(fmt.Sprintf("rid: invalid prefix %q", prefix))
+	}
+	var n = DefaultPrefixedLength
+	if t, ok := LookupPrefix(prefix); ok {
+		n = t.Length
+	}
+	return prefix + "_" + NewRIDn(n)
+}
+
+// ParsePrefixed returns the registration of the prefix of id and the base62 part,
+// ErrUnknownPrefix if the prefix is not registered
+func ParsePrefixed(id string) (PrefixType, string, error) {
+	var i = strings.LastIndexByte(id, '_')
+	if i < 0 {
+		return PrefixType{}, "", ErrInvalidPrefixed
+	}
+	t, ok := LookupPrefix(id[:i])
+	if !ok {
+		return PrefixType{}, "", ErrUnknownPrefix
+	}
+	var body = id[i+1:]
+	if len(body) != t.Length || !b62regexp.MatchString(body) {
+		return PrefixType{}, "", ErrInvalidPrefixed
+	}
+	return t, body, nil
+}
+
+// ValidPrefix is the rule for all prefixes of the package: lowercase letters, digits and inner underscores
+func ValidPrefix(prefix string) bool {
+	if prefix == "" || prefix[0] == '_' || prefix[len(prefix)-1] == '_' {
+		return false
+	}
+	for i := 0; i < len(prefix); i++ {
+		var c = prefix[i]
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			return false
+		}
+	}
+	return true
+}
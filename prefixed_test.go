@@ -0,0 +1,35 @@
+package rid
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_prefixed(t *testing.T) {
+	if err := RegisterPrefix("cus", "customer", 14); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterPrefix("cus", "other", 14); err == nil {
+		t.Fatalf("duplicate prefix registered")
+	}
+	if err := RegisterPrefix("Cus", "customer", 14); err == nil {
+		t.Fatalf("invalid prefix registered")
+	}
+	var id = NewPrefixedID("cus")
+	if !strings.HasPrefix(id, "cus_") || len(id) != 18 {
+		t.Fatalf("unexpected prefixed ID %s\n", id)
+	}
+	pt, body, err := ParsePrefixed(id)
+	if err != nil || pt.Entity != "customer" || body != id[4:] {
+		t.Fatalf("ParsePrefixed(%s) = %v, %s, %v\n", id, pt, body, err)
+	}
+	if _, _, err = ParsePrefixed(id[:17]); err != ErrInvalidPrefixed {
+		t.Fatalf("wrong length accepted")
+	}
+	if id = NewPrefixedID("sub_sched"); len(id) != 10+DefaultPrefixedLength {
+		t.Fatalf("unexpected prefixed ID %s\n", id)
+	}
+	if _, _, err = ParsePrefixed(id); err != ErrUnknownPrefix {
+		t.Fatalf("unregistered prefix accepted")
+	}
+}
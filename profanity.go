@@ -0,0 +1,73 @@
+package rid
+
+import (
+	_ "embed"
+	"errors"
+	"strings"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Screening of generated IDs, e.g. against profanity in customer-visible codes
+///////////////////////////////////////////////////////////////////////////
+
+//go:embed wordlists/profanity.txt
+var profanityFile string
+
+var profanity = parseWordlist(profanityFile)
+
+var ErrTooManyRetries = errors.New("rid: no acceptable ID after too many attempts")
+
+// a generator gives up after this many rejected IDs in a row, which only happens with a badly chosen filter
+const maxRetries = 1000
+
+var leetReplacer = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t")
+
+// one word per line, empty lines and # comments skipped
+func parseWordlist(s string) []string {
+	var words []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			words = append(words, line)
+		}
+	}
+	return words
+}
+
+// ContainsProfanity reports whether s contains any word of the embedded multilingual list or of extra.
+// Matching is case-insensitive and undoes leetspeak digits.
+func ContainsProfanity(s string, extra ...string) bool {
+	var normalized = leetReplacer.Replace(strings.ToLower(s))
+	for _, list := range [][]string{profanity, extra} {
+		for _, w := range list {
+			if strings.Contains(normalized, leetReplacer.Replace(strings.ToLower(w))) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WithFilter makes the generator re-roll any ID for which reject returns true.
+// The filter sees the random part, before the signature is appended.
+func WithFilter(reject func(id string) bool) Option {
+	return func(g *Generator) {
+		g.filters = append(g.filters, reject)
+	}
+}
+
+// WithProfanityFilter re-rolls IDs that contain profanity, see ContainsProfanity
+func WithProfanityFilter(extra ...string) Option {
+	return WithFilter(func(id string) bool {
+		return ContainsProfanity(id, extra...)
+	})
+}
+
+func (g *Generator) rejected(id string) bool {
+	for _, reject := range g.filters {
+		if reject(id) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package rid
+
+import (
+	"testing"
+)
+
+func Test_containsProfanity(t *testing.T) {
+	for _, s := range []string{"xxSHITxx", "a5s", "Merde", "qqqcoño"} {
+		if !ContainsProfanity(s) {
+			t.Fatalf("profanity not detected in %s\n", s)
+		}
+	}
+	if ContainsProfanity("Xq7Zp") || !ContainsProfanity("Xq7Zp", "q7z") {
+		t.Fatalf("unexpected result for custom list")
+	}
+}
+
+func Test_profanityFilter(t *testing.T) {
+	var g = New(WithLength(3), WithAlphabet("abs"), WithProfanityFilter())
+	for i := 0; i < 100; i++ {
+		if id := g.NewID(); ContainsProfanity(id) {
+			t.Fatalf("filtered generator returned %s\n", id)
+		}
+	}
+	var impossible = New(WithAlphabet("as"), WithFilter(func(string) bool { return true }))
+	if _, err := impossible.NewIDE(); err != ErrTooManyRetries {
+		t.Fatalf("expected ErrTooManyRetries, got %v\n", err)
+	}
+}
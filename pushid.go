@@ -0,0 +1,89 @@
+package rid
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Firebase push IDs, 8 chars of millisecond timestamp and 12 random chars in a 64-char alphabet sorted by ASCII
+///////////////////////////////////////////////////////////////////////////
+
+const PushIDAlphabet = "-0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz"
+
+var pushID = newCharset(PushIDAlphabet)
+
+var ErrInvalidPushID = errors.New("rid: invalid push ID")
+
+func NewPushID() string {
+	return defaultPushIDs.New()
+}
+
+var defaultPushIDs = NewPushIDGenerator()
+
+// PushIDGenerator increments the random part of the previous ID within the same millisecond, as Firebase clients do,
+// so its IDs are strictly increasing. If the clock goes back the last timestamp is kept.
+type PushIDGenerator struct {
+	lk     sync.Mutex
+	lastMs int64
+	last   [20]byte
+}
+
+func NewPushIDGenerator() *PushIDGenerator {
+	return &PushIDGenerator{lastMs: -1}
+}
+
+func (g *PushIDGenerator) New() string {
+	return g.NewAt(time.Now())
+}
+
+func (g *PushIDGenerator) NewAt(t time.Time) string {
+	var ms = t.UnixMilli()
+	g.lk.Lock()
+	defer g.lk.Unlock()
+	if ms <= g.lastMs {
+		// increment the random part, a carry out of it is practically impossible and wraps around
+		for i := 19; i >= 8; i-- {
+			var c = pushIDIndex(g.last[i]) + 1
+			if c < 64 {
+				g.last[i] = PushIDAlphabet[c]
+				break
+			}
+			g.last[i] = PushIDAlphabet[0]
+		}
+		return string(g.last[:])
+	}
+	g.lastMs = ms
+	for i := 7; i >= 0; i-- {
+		g.last[i] = PushIDAlphabet[ms&63]
+		ms >>= 6
+	}
+	copy(g.last[8:], pushID.newString(12))
+	return string(g.last[:])
+}
+
+func pushIDIndex(c byte) int {
+	for i := 0; i < len(PushIDAlphabet); i++ {
+		if PushIDAlphabet[i] == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// TimeFromPushID decodes the 8-char timestamp prefix
+func TimeFromPushID(id string) (time.Time, error) {
+	if !ValidPushID(id) {
+		return time.Time{}, ErrInvalidPushID
+	}
+	var ms int64
+	for i := 0; i < 8; i++ {
+		ms = ms<<6 | int64(pushIDIndex(id[i]))
+	}
+	return time.UnixMilli(ms), nil
+}
+
+func ValidPushID(s string) bool {
+	return len(s) == 20 && pushID.valid(s)
+}
@@ -0,0 +1,28 @@
+package rid
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_pushID(t *testing.T) {
+	var g = NewPushIDGenerator()
+	var now = time.UnixMilli(1700000000123)
+	var prev string
+	for i := 0; i < 1000; i++ {
+		var id = g.NewAt(now)
+		if !ValidPushID(id) || id <= prev {
+			t.Fatalf("push IDs not increasing: %s <= %s\n", id, prev)
+		}
+		prev = id
+	}
+	if tm, err := TimeFromPushID(prev); err != nil || !tm.Equal(now) {
+		t.Fatalf("unexpected time %v, %v\n", tm, err)
+	}
+	if later := g.NewAt(now.Add(time.Millisecond)); later <= prev {
+		t.Fatalf("push IDs not increasing: %s <= %s\n", later, prev)
+	}
+	if ValidPushID(prev[1:]) || ValidPushID(prev[1:]+"+") {
+		t.Fatalf("invalid push IDs accepted")
+	}
+}
@@ -0,0 +1,78 @@
+package rid
+
+import (
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// 2FA recovery codes, grouped CouponAlphabet chars stored as salted PBKDF2 hashes
+///////////////////////////////////////////////////////////////////////////
+
+// 10-char codes carry 47 bits, the iterations make an offline search of a leaked hash expensive
+const recoveryIterations = 100000
+
+// NewRecoveryCodes returns count distinct codes of length chars from crypto/rand, in groups of 5 for display,
+// and their hashes for storage. Store only the hashes and delete a hash once its code has been used.
+func NewRecoveryCodes(count, length int) (codes []string, hashes []string, err error) {
+	if count < 1 || length < 8 {
+		return nil, nil, fmt.Errorf("rid: invalid recovery code count %d or length %d", count, length)
+	}
+	var seen = make(map[string]bool, count)
+	for len(codes) < count {
+		b, err := appendAlphabet(make([]byte, 0, length), cryptoReader(), []byte(CouponAlphabet), length)
+		if err != nil {
+			return nil, nil, err
+		}
+		if seen[string(b)] {
+			continue
+		}
+		seen[string(b)] = true
+		hash, err := hashRecoveryCode(string(b))
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, Group(string(b), 5, '-'))
+		hashes = append(hashes, hash)
+	}
+	return codes, hashes, nil
+}
+
+// VerifyRecoveryCode checks code as typed by the user, in any case and with or without hyphens and spaces
+func VerifyRecoveryCode(code, hash string) bool {
+	var parts = strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != "pbkdf2-sha256" {
+		return false
+	}
+	iter, err := strconv.Atoi(parts[1])
+	if err != nil || iter < 1 {
+		return false
+	}
+	salt, err1 := base64.RawStdEncoding.DecodeString(parts[2])
+	want, err2 := base64.RawStdEncoding.DecodeString(parts[3])
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	got, err := pbkdf2.Key(sha256.New, strings.ToUpper(Normalize(code)), salt, iter, len(want))
+	return err == nil && subtle.ConstantTimeCompare(got, want) == 1
+}
+
+// pbkdf2-sha256$iterations$salt$key with unpadded base64
+func hashRecoveryCode(code string) (string, error) {
+	var salt = make([]byte, 16)
+	if _, err := io.ReadFull(cryptoReader(), salt); err != nil {
+		return "", err
+	}
+	key, err := pbkdf2.Key(sha256.New, code, salt, recoveryIterations, 32)
+	if err != nil {
+		return "", err
+	}
+	return "pbkdf2-sha256$" + strconv.Itoa(recoveryIterations) + "$" +
+		base64.RawStdEncoding.EncodeToString(salt) + "$" + base64.RawStdEncoding.EncodeToString(key), nil
+}
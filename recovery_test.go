@@ -0,0 +1,29 @@
+package rid
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func Test_recoveryCodes(t *testing.T) {
+	codes, hashes, err := NewRecoveryCodes(4, 10)
+	if err != nil || len(codes) != 4 || len(hashes) != 4 {
+		t.Fatalf("NewRecoveryCodes = %v, %v, %v\n", codes, hashes, err)
+	}
+	var format = regexp.MustCompile(`^[` + CouponAlphabet + `]{5}-[` + CouponAlphabet + `]{5}$`)
+	for i, code := range codes {
+		if !format.MatchString(code) {
+			t.Fatalf("unexpected recovery code %s\n", code)
+		}
+		if !VerifyRecoveryCode(code, hashes[i]) || !VerifyRecoveryCode(strings.ToLower(Ungroup(code, '-')), hashes[i]) {
+			t.Fatalf("recovery code %s not verified\n", code)
+		}
+		if VerifyRecoveryCode(codes[(i+1)%4], hashes[i]) {
+			t.Fatalf("other recovery code verified\n")
+		}
+	}
+	if VerifyRecoveryCode(codes[0], "garbage") {
+		t.Fatalf("malformed hash accepted")
+	}
+}
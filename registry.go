@@ -0,0 +1,75 @@
+package rid
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Named formats, configured once and referenced by name across packages
+///////////////////////////////////////////////////////////////////////////
+
+var formats struct {
+	lk sync.RWMutex
+	m  map[string]Source
+}
+
+// Register makes src available as Format(name). It is meant to be called from init and panics
+// if name is registered twice, like database/sql.Register.
+//
+//	rid.Register("order", rid.New(rid.WithLength(16), rid.WithSigner(secret)))
+func Register(name string, src Source) {
+	formats.lk.Lock()
+	defer formats.lk.Unlock()
+	if src == nil {
+		panic("rid: Register source is nil")
+	}
+	if _, dup := formats.m[name]; dup {
+		panic("rid: Register called twice for format " + name)
+	}
+	if formats.m == nil {
+		formats.m = make(map[string]Source)
+	}
+	formats.m[name] = src
+}
+
+// Format returns the source registered as name and panics if there is none, since a missing format is a setup error.
+//
+//	id := rid.Format("order").NewID()
+func Format(name string) Source {
+	src, ok := LookupFormat(name)
+	if !ok {
+		panic(fmt.Sprintf("rid: unknown format %q (forgotten import?)", name))
+	}
+	return src
+}
+
+func LookupFormat(name string) (Source, bool) {
+	formats.lk.RLock()
+	defer formats.lk.RUnlock()
+	src, ok := formats.m[name]
+	return src, ok
+}
+
+// ValidFormat checks id with the Valid method of the registered source, false if it has none
+func ValidFormat(name string, id string) bool {
+	src, ok := LookupFormat(name)
+	if !ok {
+		return false
+	}
+	v, ok := src.(interface{ Valid(string) bool })
+	return ok && v.Valid(id)
+}
+
+// Formats returns the sorted names of the registered formats
+func Formats() []string {
+	formats.lk.RLock()
+	defer formats.lk.RUnlock()
+	var names = make([]string, 0, len(formats.m))
+	for name := range formats.m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
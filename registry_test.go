@@ -0,0 +1,26 @@
+package rid
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_registry(t *testing.T) {
+	Register("test_order", New(WithLength(12), WithSigner("secret")))
+	var id = Format("test_order").NewID()
+	if len(id) != 28 || !ValidFormat("test_order", id) || ValidFormat("test_order", id[1:]) {
+		t.Fatalf("unexpected ID %s of registered format\n", id)
+	}
+	if !slices.Contains(Formats(), "test_order") {
+		t.Fatalf("format not listed: %v\n", Formats())
+	}
+	if _, ok := LookupFormat("test_missing"); ok || ValidFormat("test_missing", id) {
+		t.Fatalf("unregistered format found")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("duplicate Register did not panic")
+		}
+	}()
+	Register("test_order", New())
+}
@@ -0,0 +1,74 @@
+package rid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Password-reset and similar single-purpose tokens: 16 random bytes, expiry and purpose, signed with HMAC-SHA256
+///////////////////////////////////////////////////////////////////////////
+
+var ErrTokenExpired = errors.New("rid: token expired")
+
+// malformed, forged or signed with another secret
+var ErrTokenTampered = errors.New("rid: token tampered")
+
+var ErrTokenPurpose = errors.New("rid: token issued for another purpose")
+
+type ResetToken struct {
+	// base62 of the random bytes, to record a used token until it expires
+	ID      string
+	Purpose string
+	Expires time.Time
+}
+
+// NewResetToken returns base64url of the payload and of its MAC joined by a dot.
+// The purpose, e.g. "password-reset" or "email-verify", keeps a token from being replayed elsewhere.
+func NewResetToken(purpose string, ttl time.Duration, secret string) string {
+	return newResetTokenAt(purpose, time.Now().Add(ttl), secret)
+}
+
+func newResetTokenAt(purpose string, expires time.Time, secret string) string {
+	var payload = make([]byte, 24, 24+len(purpose))
+	copy(payload, randomBytes(16))
+	binary.BigEndian.PutUint64(payload[16:], uint64(expires.Unix()))
+	payload = append(payload, purpose...)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(resetMAC(payload, secret))
+}
+
+// ValidateResetToken verifies the signature first, so that ErrTokenPurpose and ErrTokenExpired are returned
+// only for genuine tokens. Single use is up to the caller, e.g. by storing the ID until expiry.
+func ValidateResetToken(purpose, token, secret string, now time.Time) (ResetToken, error) {
+	p, m, ok := strings.Cut(token, ".")
+	payload, err1 := base64.RawURLEncoding.DecodeString(p)
+	mac, err2 := base64.RawURLEncoding.DecodeString(m)
+	if !ok || err1 != nil || err2 != nil || len(payload) < 24 || !hmac.Equal(mac, resetMAC(payload, secret)) {
+		return ResetToken{}, ErrTokenTampered
+	}
+	id, _ := intToDigits(new(big.Int).SetBytes(payload[:16]), Base62Alphabet, 22)
+	var t = ResetToken{
+		ID:      id,
+		Purpose: string(payload[24:]),
+		Expires: time.Unix(int64(binary.BigEndian.Uint64(payload[16:24])), 0),
+	}
+	if t.Purpose != purpose {
+		return t, ErrTokenPurpose
+	}
+	if !now.Before(t.Expires) {
+		return t, ErrTokenExpired
+	}
+	return t, nil
+}
+
+func resetMAC(payload []byte, secret string) []byte {
+	var mac = hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
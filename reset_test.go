@@ -0,0 +1,29 @@
+package rid
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_resetToken(t *testing.T) {
+	var now = time.Unix(1700000000, 0)
+	var token = newResetTokenAt("password-reset", now.Add(time.Hour), "secret")
+	rt, err := ValidateResetToken("password-reset", token, "secret", now)
+	if err != nil || rt.Purpose != "password-reset" || !rt.Expires.Equal(now.Add(time.Hour)) || len(rt.ID) != 22 {
+		t.Fatalf("ValidateResetToken = %v, %v\n", rt, err)
+	}
+	if _, err = ValidateResetToken("password-reset", token, "secret", now.Add(time.Hour)); err != ErrTokenExpired {
+		t.Fatalf("expired token: %v\n", err)
+	}
+	if _, err = ValidateResetToken("password-reset", token, "other", now); err != ErrTokenTampered {
+		t.Fatalf("wrong secret: %v\n", err)
+	}
+	// extending the expiry invalidates the signature
+	var forged = newResetTokenAt("password-reset", now.Add(time.Hour), "attacker")
+	if _, err = ValidateResetToken("password-reset", forged[:43]+token[43:], "secret", now); err != ErrTokenTampered {
+		t.Fatalf("forged token: %v\n", err)
+	}
+	if _, err = ValidateResetToken("email-verify", token, "secret", now); err != ErrTokenPurpose {
+		t.Fatalf("wrong purpose: %v\n", err)
+	}
+}
@@ -5,14 +5,22 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
-	"fmt"
+	"errors"
+	"io"
 	"log"
 	"math"
 	"math/big"
+	"math/bits"
 	mathrand "math/rand"
+	randv2 "math/rand/v2"
 	"regexp"
+	"runtime"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
 )
 
 ///////////////////////////////////////////////////////////////////////////
@@ -23,13 +31,140 @@ var B62ascii = []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz01234
 var b62asciiMod = []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789")
 var b62regexp = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
 
+// Shards of crypto-seeded ChaCha8 generators, one per P, so that concurrent callers don't serialize on a single lock.
+// A shard is picked by a counter, which spreads goroutines evenly without any per-goroutine state.
 type internalRandType struct {
-	lk sync.Mutex
-	r1 *mathrand.Rand
-	r2 *mathrand.Rand
+	next   atomic.Uint32
+	shards []randShard
+	policy ReseedPolicy
 }
 
-var internalRand = &internalRandType{r1: mathrand.New(mathrand.NewSource(NewInt63Crypto())), r2: mathrand.New(mathrand.NewSource(NewInt63Crypto()))}
+type randShard struct {
+	lk     sync.Mutex
+	c      *randv2.ChaCha8
+	uses   int
+	seeded time.Time
+	// keep shards on separate cache lines
+	_ [16]byte
+}
+
+// ReseedPolicy controls when the internal generator takes a fresh seed from crypto/rand.
+// Zero fields disable the respective trigger, Reseed can always be called manually.
+type ReseedPolicy struct {
+	// reseed a shard after it served this many IDs
+	Every int
+	// reseed a shard when its seed is older than this
+	Interval time.Duration
+}
+
+// reseed on average as often as the former probabilistic trigger (two zero bytes) did
+var DefaultReseedPolicy = ReseedPolicy{Every: 1 << 16}
+
+var internalRand = newInternalRand(runtime.GOMAXPROCS(0), DefaultReseedPolicy)
+
+func newInternalRand(n int, policy ReseedPolicy) *internalRandType {
+	// power of two, so that the shard is selected with a mask
+	var size = 1 << bits.Len(uint(n-1))
+	var r = &internalRandType{shards: make([]randShard, size), policy: policy}
+	var now = time.Now()
+	for i := range r.shards {
+		r.shards[i].c = randv2.NewChaCha8(newSeed32())
+		r.shards[i].seeded = now
+	}
+	return r
+}
+
+func newSeed32() [32]byte {
+	var seed [32]byte
+	if _, err := rand.Read(seed[:]); err != nil {
+		//severe error - looks like a failure of system random number generator
+		log.Fatal(err)
+	}
+	return seed
+}
+
+// get returns a locked shard, reseeded if the policy says so, release it with put
+func (r *internalRandType) get() *randShard {
+	var s = &r.shards[r.next.Add(1)&uint32(len(r.shards)-1)]
+	s.lk.Lock()
+	if (r.policy.Every > 0 && s.uses >= r.policy.Every) || (r.policy.Interval > 0 && time.Since(s.seeded) > r.policy.Interval) {
+		s.reseed()
+	}
+	s.uses++
+	return s
+}
+
+func (r *internalRandType) put(s *randShard) {
+	s.lk.Unlock()
+}
+
+// must be called with the shard locked
+func (s *randShard) reseed() {
+	s.c.Seed(newSeed32())
+	s.uses = 0
+	s.seeded = time.Now()
+}
+
+func (r *internalRandType) reseedAll() {
+	for i := range r.shards {
+		var s = &r.shards[i]
+		s.lk.Lock()
+		s.reseed()
+		s.lk.Unlock()
+	}
+}
+
+// Reseed takes fresh seeds from crypto/rand for the package's internal generator.
+// Call it after fork or VM snapshot restore, when the generator state may be shared with another process.
+func Reseed() {
+	internalRand.reseedAll()
+}
+
+// Read makes the internal generator usable as io.Reader
+func (r *internalRandType) Read(p []byte) (int, error) {
+	var s = r.get()
+	s.c.Read(p)
+	r.put(s)
+	return len(p), nil
+}
+
+// caller-supplied entropy source, see SetRand
+var customRand struct {
+	lk sync.RWMutex
+	r  io.Reader
+}
+
+// SetRand makes NewRIDn and NewRIDnCrypto read randomness from r instead of the built-in sources.
+// Use it to inject an HSM-backed reader or a deterministic source in tests. SetRand(nil) restores the defaults.
+func SetRand(r io.Reader) {
+	customRand.lk.Lock()
+	defer customRand.lk.Unlock()
+	customRand.r = r
+}
+
+func getCustomRand() io.Reader {
+	customRand.lk.RLock()
+	defer customRand.lk.RUnlock()
+	return customRand.r
+}
+
+// crypto/rand unless replaced with SetRand
+func cryptoReader() io.Reader {
+	if r := getCustomRand(); r != nil {
+		return r
+	}
+	return rand.Reader
+}
+
+// n random bytes from the same source as NewRIDn
+func randomBytes(n int) []byte {
+	var b = make([]byte, n)
+	if _, err := io.ReadFull(packageReader(), b); err != nil {
+		//severe error - looks like a failure of random number generator
+		log.Fatal(err)
+	}
+	return b
+}
 
 // RID16: 16-chars of base62 gives about 95.3 bits of entropy
 // This gives the space of about 10^10 generated ids with probability of collision = 10^-9 according to birthday paradox calcs
@@ -42,6 +177,32 @@ func NewRID20() string {
 	return NewRIDn(20)
 }
 
+// RID22: exactly 128 random bits in 22-chars of base62, convertible to 16 bytes and back
+// for binary (UUID column) storage. Not every 22-char base62 string is a RID22, see ValidRID22.
+func NewRID22() string {
+	var b [16]byte
+	copy(b[:], randomBytes(16))
+	return RID22FromBytes(b)
+}
+
+func RID22FromBytes(b [16]byte) string {
+	s, _ := intToB62(new(big.Int).SetBytes(b[:]), 22)
+	return s
+}
+
+func RID22Bytes(rid string) ([16]byte, error) {
+	var b [16]byte
+	if len(rid) != 22 {
+		return b, ErrInvalidRID
+	}
+	x, ok := b62ToInt(rid)
+	if !ok || x.BitLen() > 128 {
+		return b, ErrInvalidRID
+	}
+	x.FillBytes(b[:])
+	return b, nil
+}
+
 // total length should be 36 characters
 func NewRID20Signed(secret string) string {
 	var r = NewRIDn(20)
@@ -49,56 +210,178 @@ func NewRID20Signed(secret string) string {
 }
 
 func NewRID20SignedBatch(secret string, n int) []string {
-	var result = make([]string, n)
-	for i := 0; i < n; i++ {
-		result[i] = NewRID20Signed(secret)
+	var result = NewRIDBatch(20, n)
+	for i, r := range result {
+		result[i] = r + HMAC(r, secret)
+	}
+	return result
+}
+
+// NewRIDBatch returns count n-char RIDs generated in a single pass over the random source.
+// The IDs share one backing string, so keeping any of them keeps the whole batch in memory.
+func NewRIDBatch(n, count int) []string {
+	var all = string(AppendRIDn(make([]byte, 0, n*count), n*count))
+	var result = make([]string, count)
+	for i := range result {
+		result[i] = all[i*n : (i+1)*n]
 	}
 	return result
 }
 
 // first 16 characters of hexed sha256 hmac
 func HMAC(message string, secret string) string {
+	return HMACn(message, secret, MAC64)
+}
+
+// Truncation lengths of the MAC in bytes for HMACn and WithMACLength
+const (
+	MAC64  = 8
+	MAC128 = 16
+	MAC256 = 32
+)
+
+// HMACBase62 is HMACn rendered in base62, 11 characters for MAC64, 22 for MAC128 and 43 for MAC256
+func HMACBase62(message string, secret string, n int) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	s, _ := intToDigits(new(big.Int).SetBytes(mac.Sum(nil)[:n]), Base62Alphabet, base62Width(n))
+	return s
+}
+
+// base62 characters needed for n bytes
+func base62Width(n int) int {
+	return int(math.Ceil(float64(8*n) / math.Log2(62)))
+}
+
+// NewRID20SignedB62 is NewRID20Signed with the MAC in 11 base62 characters, so the whole ID is [A-Za-z0-9]{31}
+func NewRID20SignedB62(secret string) string {
+	r := NewRID20()
+	return r + HMACBase62(r, secret, MAC64)
+}
+
+func ValidRID20SignedB62(r string, secret string) bool {
+	if len(r) != 31 || !b62regexp.MatchString(r) {
+		return false
+	}
+	return hmac.Equal([]byte(r[20:]), []byte(HMACBase62(r[:20], secret, MAC64)))
+}
+
+// HMACn is HMAC truncated to n bytes instead of 8, in 2n hex characters
+func HMACn(message string, secret string, n int) string {
 	mac := hmac.New(sha256.New, []byte(secret))
 	mac.Write([]byte(message))
 	bytes := mac.Sum(nil)
-	return hex.EncodeToString(bytes[:8])
+	return hex.EncodeToString(bytes[:n])
+}
+
+// BlindIndex is a keyed hash of value in length base62 characters, 1 to 43, for equality search
+// on encrypted columns: store it next to the ciphertext and look up by the BlindIndex of the search term.
+// Use a key other than the encryption key. Shorter indexes leak less about the values and give more false positives.
+func BlindIndex(value, key string, length int) string {
+	if length < 1 || length > base62Width(sha256.Size) {
+		panic("rid: blind index length " + strconv.Itoa(length) + " out of range 1..43")
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(value))
+	// the low digits, the leading digit of the full MAC is not uniform
+	s, _ := intToDigits(new(big.Int).SetBytes(mac.Sum(nil)), Base62Alphabet, length)
+	return s
 }
 
 // Optimized version, should be crypto secure
 func NewRIDn(n int) string {
-	internalRand.lk.Lock()
-	defer internalRand.lk.Unlock()
-	var b = make([]byte, n)
-	var b1 = make([]byte, n/2+1)
-	var b2 = make([]byte, n/2+1)
-	internalRand.r1.Read(b1)
-	internalRand.r2.Read(b2)
-	var c byte
-	for i := 0; i < n; i++ {
-		if i%2 == 0 {
-			c = b1[i/2]
-		} else {
-			c = b2[i/2]
+	if n <= 64 {
+		var buf [64]byte
+		return string(AppendRIDn(buf[:0], n))
+	}
+	return string(AppendRIDn(make([]byte, 0, n), n))
+}
+
+func AppendRID16(dst []byte) []byte {
+	return AppendRIDn(dst, 16)
+}
+
+func AppendRID20(dst []byte) []byte {
+	return AppendRIDn(dst, 20)
+}
+
+// AppendRIDn appends n-char RID to dst and returns the extended buffer, like strconv.AppendInt.
+// With the built-in generator and enough capacity in dst it does not allocate.
+func AppendRIDn(dst []byte, n int) []byte {
+	var err error
+	if g := Default(); g != nil {
+		dst, err = g.appendRandom(dst, n)
+	} else if r := getCustomRand(); r != nil {
+		dst, err = appendAlphabet(dst, r, B62ascii, n)
+	} else if FIPSMode() {
+		dst, err = appendAlphabet(dst, rand.Reader, B62ascii, n)
+	} else {
+		return appendInternal(dst, n)
+	}
+	if err != nil {
+		//severe error - looks like a failure of the supplied random number generator
+		log.Fatal(err)
+	}
+	return dst
+}
+
+func appendInternal(dst []byte, n int) []byte {
+	var s = internalRand.get()
+	defer internalRand.put(s)
+	return appendB62(dst, s.c, n)
+}
+
+// Rejection sampling: bytes below 248 (the largest multiple of 62 that fits in a byte) map to b62asciiMod,
+// each character being hit by exactly 4 byte values. Bytes 248-255 are discarded, so the output is uniform.
+func appendB62(dst []byte, c *randv2.ChaCha8, n int) []byte {
+	var buf [64]byte
+	for n > 0 {
+		// a few spare bytes to cover the expected 1/32 rejections
+		var m = n + n/16 + 1
+		if m > len(buf) {
+			m = len(buf)
 		}
-		if c >= 248 {
-			c = byte(internalRand.r1.Intn(62))
+		c.Read(buf[:m])
+		for _, x := range buf[:m] {
+			if x < 248 {
+				dst = append(dst, b62asciiMod[x])
+				n--
+				if n == 0 {
+					break
+				}
+			}
 		}
-		b[i] = b62asciiMod[c]
-	}
-	// reseed with crypto seed from time to time
-	if b1[0] == 0 && b2[0] == 0 {
-		internalRand.r1.Seed(NewInt63Crypto())
-		internalRand.r2.Seed(NewInt63Crypto())
 	}
-	return string(b)
+	return dst
 }
 
+// NewNID returns up to 9 digits without leading zeros, see NewOTP for a fixed number of uniform digits
 func NewNID() string {
 	return strconv.Itoa(int(NewInt63Crypto() % 1000000000))
 }
 
 func DashNID(nid string) string {
-	return fmt.Sprintf("%s-%s-%s", nid[:3], nid[3:6], nid[6:])
+	return Group(nid, 3, '-')
+}
+
+// Group splits id into groups of size characters joined by sep, e.g. Group(rid, 4, '-') for printing
+func Group(id string, size int, sep rune) string {
+	if size <= 0 || len(id) <= size {
+		return id
+	}
+	var b strings.Builder
+	for i := 0; i < len(id); i += size {
+		if i > 0 {
+			b.WriteRune(sep)
+		}
+		b.WriteString(id[i:min(i+size, len(id))])
+	}
+	return b.String()
+}
+
+// Ungroup is the inverse of Group, it removes every sep from id
+func Ungroup(id string, sep rune) string {
+	return strings.ReplaceAll(id, string(sep), "")
 }
 
 ///////////////////////////////////////////////////////////////////////////
@@ -107,48 +390,79 @@ func DashNID(nid string) string {
 
 // RID16: 16-chars of base62 gives about 95.3 bits of entropy
 // This gives the space of about 10^10 generated ids with probability of collision = 10^-9 according to birthday paradox calcs
+//
+// Deprecated: use rid/v2 RID16(WithCrypto()).
 func NewRID16Crypto() string {
 	return NewRIDnCrypto(16)
 }
 
 // RID20: 20-chars of base62 gives 119.1 bits of entropy
+//
+// Deprecated: use rid/v2 RID20(WithCrypto()).
 func NewRID20Crypto() string {
 	return NewRIDnCrypto(20)
 }
 
+// Deprecated: use rid/v2 New(WithLength(n), WithCrypto()), which returns the random source failure.
 func NewRIDnCrypto(n int) string {
-	var b = make([]byte, n)
-	for i := 0; i < n; i++ {
-		biggie, err := rand.Int(rand.Reader, big.NewInt(62))
-		if err != nil {
-			//severe error - looks like a failure of system random number generator
-			log.Fatal(err)
-		}
-		b[i] = B62ascii[biggie.Int64()]
+	r, err := NewRIDnCryptoE(n)
+	if err != nil {
+		//severe error - looks like a failure of system random number generator
+		log.Fatal(err)
 	}
-	return string(b)
+	return r
+}
+
+// As NewRIDnCrypto but returns the random number generator failure to the caller.
+// Random bytes are read in bulk and rejection sampled, see appendAlphabet.
+//
+// Deprecated: use rid/v2 New(WithLength(n), WithCrypto()).
+func NewRIDnCryptoE(n int) (string, error) {
+	b, err := appendAlphabet(make([]byte, 0, n), cryptoReader(), B62ascii, n)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
 }
 
 func NewInt63Crypto() int64 {
-	biggie, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	r, err := NewInt63CryptoE()
 	if err != nil {
 		//severe error - looks like a failure of system random number generator
 		log.Fatal(err)
 	}
-	return biggie.Int64()
+	return r
+}
+
+// As NewInt63Crypto but returns the random number generator failure to the caller
+func NewInt63CryptoE() (int64, error) {
+	biggie, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	if err != nil {
+		return 0, err
+	}
+	return biggie.Int64(), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////
 // Replacements for testing purposes
 ///////////////////////////////////////////////////////////////////////////
 
+// Deprecated: use DeterministicGenerator, or the generators in package ridtest.
 func NewRID16Math() string {
 	return NewRIDnMath(16)
 }
+
+// Deprecated: use DeterministicGenerator, or the generators in package ridtest.
 func NewRID20Math() string {
 	return NewRIDnMath(20)
 }
+
+// Deprecated: use DeterministicGenerator(seed, WithLength(n)), which unlike the global math/rand state
+// can be seeded per test. In FIPS mode it is NewRIDnCrypto.
 func NewRIDnMath(n int) string {
+	if FIPSMode() {
+		return NewRIDnCrypto(n)
+	}
 	var b = make([]byte, n)
 	for i := 0; i < n; i++ {
 		b[i] = B62ascii[mathrand.Intn(62)]
@@ -168,15 +482,69 @@ func ValidRID20(rid string) bool {
 	return len(rid) == 20 && b62regexp.MatchString(rid)
 }
 
+// 22-chars of base62 with value below 2^128
+func ValidRID22(rid string) bool {
+	_, err := RID22Bytes(rid)
+	return err == nil
+}
+
 func ValidRID20Signed(r string, secret string) bool {
-	//fmt.Printf("\n\n\nr=%v, len(r)=%v\n\n", r, len(r))
+	return VerifySignedRID(r, secret) == nil
+}
+
+// ValidRID20SignedAny accepts an ID signed with any of secrets,
+// e.g. the new and the previous secret during the grace period of a rotation
+func ValidRID20SignedAny(r string, secrets ...string) bool {
+	for _, secret := range secrets {
+		if VerifySignedRID(r, secret) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	ErrBadLength    = errors.New("rid: bad length")
+	ErrBadAlphabet  = errors.New("rid: bad alphabet")
+	ErrBadSignature = errors.New("rid: bad signature")
+)
+
+// VerifySignedRID checks a NewRID20Signed ID like ValidRID20Signed and tells why it failed.
+// The errors don't reveal anything about the expected signature, so they are safe to log.
+func VerifySignedRID(r string, secret string) error {
 	if len(r) != 36 {
-		return false
+		return ErrBadLength
 	}
 	rid, hexed := r[:20], r[20:]
-	//fmt.Printf("\n\nrid=%v, hexed=%v\n\n", rid, hexed)
 	if !ValidRID20(rid) {
-		return false
+		return ErrBadAlphabet
+	}
+	if !hmac.Equal([]byte(hexed), []byte(HMAC(rid, secret))) {
+		return ErrBadSignature
 	}
-	return hexed == HMAC(rid, secret)
+	return nil
+}
+
+// Normalize removes hyphens and whitespace anywhere in id, as left by Group or by pasting from emails and PDFs
+func Normalize(id string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, id)
+}
+
+// Lenient validators accept the ID after Normalize
+
+func ValidRID16Lenient(rid string) bool {
+	return ValidRID16(Normalize(rid))
+}
+
+func ValidRID20Lenient(rid string) bool {
+	return ValidRID20(Normalize(rid))
+}
+
+func ValidRID20SignedLenient(r string, secret string) bool {
+	return ValidRID20Signed(Normalize(r), secret)
 }
@@ -1,7 +1,10 @@
 package rid
 
 import (
+	mathrand "math/rand"
+	randv2 "math/rand/v2"
 	"regexp"
+	"sync"
 	"testing"
 )
 
@@ -28,3 +31,264 @@ func Test_rid20(t *testing.T) {
 		t.Fatalf("uid20a should be different from uid20b")
 	}
 }
+
+func Test_setRand(t *testing.T) {
+	defer SetRand(nil)
+	SetRand(mathrand.New(mathrand.NewSource(1)))
+	var a, ac = NewRID20(), NewRID20Crypto()
+	SetRand(mathrand.New(mathrand.NewSource(1)))
+	var b, bc = NewRID20(), NewRID20Crypto()
+	if a != b || ac != bc {
+		t.Fatalf("same entropy should give same ids: %s %s, %s %s\n", a, b, ac, bc)
+	}
+	if !ValidRID20(a) || !ValidRID20(ac) {
+		t.Fatalf("invalid ids from custom entropy: %s %s\n", a, ac)
+	}
+}
+
+func Test_appendRID(t *testing.T) {
+	var buf = make([]byte, 0, 64)
+	buf = AppendRID16(append(buf, "id:"...))
+	if len(buf) != 19 || string(buf[:3]) != "id:" || !ValidRID16(string(buf[3:])) {
+		t.Fatalf("unexpected append result: %s\n", buf)
+	}
+	if len(AppendRIDn(nil, 150)) != 150 {
+		t.Fatalf("long RID has wrong length")
+	}
+	var allocs = testing.AllocsPerRun(100, func() {
+		buf = AppendRID20(buf[:0])
+	})
+	if allocs != 0 {
+		t.Fatalf("AppendRID20 allocates: %v\n", allocs)
+	}
+}
+
+func Test_ridBatch(t *testing.T) {
+	var ids = NewRIDBatch(16, 1000)
+	var seen = make(map[string]bool)
+	for _, id := range ids {
+		if !ValidRID16(id) || seen[id] {
+			t.Fatalf("invalid or duplicate id in batch: %s\n", id)
+		}
+		seen[id] = true
+	}
+	for _, id := range NewRID20SignedBatch("secret", 10) {
+		if !ValidRID20Signed(id, "secret") {
+			t.Fatalf("invalid signed id in batch: %s\n", id)
+		}
+	}
+}
+
+func Test_concurrentRID(t *testing.T) {
+	var wg sync.WaitGroup
+	var lk sync.Mutex
+	var seen = make(map[string]bool)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				var id = NewRID16()
+				lk.Lock()
+				if seen[id] {
+					t.Errorf("duplicate id: %s\n", id)
+				}
+				seen[id] = true
+				lk.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func Test_internalRandShards(t *testing.T) {
+	var r = newInternalRand(3, DefaultReseedPolicy)
+	if len(r.shards) != 4 {
+		t.Fatalf("expected 4 shards, got %d\n", len(r.shards))
+	}
+	var a, b [16]byte
+	r.Read(a[:])
+	r.Read(b[:])
+	if a == b {
+		t.Fatalf("shards should produce different output")
+	}
+}
+
+func Test_newRIDnAllocs(t *testing.T) {
+	if allocs := testing.AllocsPerRun(100, func() { NewRID20() }); allocs != 1 {
+		t.Fatalf("NewRID20 should only allocate the result, got %v allocs\n", allocs)
+	}
+	defer SetRand(nil)
+	SetRand(mathrand.New(mathrand.NewSource(1)))
+	if allocs := testing.AllocsPerRun(100, func() { NewRID20() }); allocs != 1 {
+		t.Fatalf("NewRID20 with custom source should only allocate the result, got %v allocs\n", allocs)
+	}
+}
+
+func Test_b62Uniform(t *testing.T) {
+	// every character must be reachable from exactly 4 of the accepted byte values
+	var counts = make(map[byte]int)
+	for _, c := range b62asciiMod[:248] {
+		counts[c]++
+	}
+	for _, c := range B62ascii {
+		if counts[c] != 4 {
+			t.Fatalf("character %c mapped from %d byte values\n", c, counts[c])
+		}
+	}
+	// chi-square goodness of fit over a fixed-seed stream, 61 degrees of freedom
+	const perChar = 10000
+	var c = randv2.NewChaCha8([32]byte{1})
+	var b = appendB62(nil, c, 62*perChar)
+	var freq [256]int
+	for _, x := range b {
+		freq[x]++
+	}
+	var chi2 float64
+	for _, x := range B62ascii {
+		var d = float64(freq[x] - perChar)
+		chi2 += d * d / perChar
+	}
+	// critical value for p = 0.001 is 100.9
+	if chi2 > 100.9 {
+		t.Fatalf("character distribution not uniform, chi2 = %.1f\n", chi2)
+	}
+}
+
+func Test_ridCrypto(t *testing.T) {
+	var a, b = NewRID20Crypto(), NewRID20Crypto()
+	if !ValidRID20(a) || !ValidRID20(b) || a == b {
+		t.Fatalf("unexpected crypto ids: %s, %s\n", a, b)
+	}
+	if r, err := NewRIDnCryptoE(500); err != nil || len(r) != 500 || !b62regexp.MatchString(r) {
+		t.Fatalf("unexpected long crypto id: %s, %v\n", r, err)
+	}
+}
+
+func Test_reseedPolicy(t *testing.T) {
+	var r = newInternalRand(1, ReseedPolicy{Every: 2})
+	var s = &r.shards[0]
+	var seeded = s.seeded
+	for i := 0; i < 3; i++ {
+		r.put(r.get())
+	}
+	if s.uses != 1 || !s.seeded.After(seeded) {
+		t.Fatalf("shard should have been reseeded after 2 uses, uses = %d\n", s.uses)
+	}
+	var before [16]byte
+	var clone = *s.c
+	clone.Read(before[:])
+	Reseed()
+	r.reseedAll()
+	var after [16]byte
+	s.c.Read(after[:])
+	if before == after {
+		t.Fatalf("reseed should change the generator output")
+	}
+}
+
+func Test_rid22(t *testing.T) {
+	var id = NewRID22()
+	b, err := RID22Bytes(id)
+	if len(id) != 22 || !ValidRID22(id) || err != nil || RID22FromBytes(b) != id {
+		t.Fatalf("RID22 round trip failed: %s, %v\n", id, err)
+	}
+	if RID22FromBytes([16]byte{}) != "AAAAAAAAAAAAAAAAAAAAAA" {
+		t.Fatalf("unexpected zero RID22")
+	}
+	if ValidRID22("9999999999999999999999") || ValidRID22(NewRID20()) {
+		t.Fatalf("RID22 validator too lenient")
+	}
+}
+
+func Test_group(t *testing.T) {
+	if s := DashNID("123456789"); s != "123-456-789" {
+		t.Fatalf("unexpected DashNID: %s\n", s)
+	}
+	var id = NewRID20()
+	var grouped = Group(id, 4, ' ')
+	if len(grouped) != 24 || Ungroup(grouped, ' ') != id {
+		t.Fatalf("group round trip failed: %s\n", grouped)
+	}
+	if s := Group("abcde", 2, '·'); s != "ab·cd·e" || Ungroup(s, '·') != "abcde" {
+		t.Fatalf("unexpected grouping: %s\n", s)
+	}
+}
+
+func Test_lenient(t *testing.T) {
+	var id = NewRID20Signed("secret")
+	var pasted = " \t" + Group(id, 4, '-') + "\n"
+	if !ValidRID20SignedLenient(pasted, "secret") || ValidRID20Signed(pasted, "secret") {
+		t.Fatalf("lenient validation mismatch for %q\n", pasted)
+	}
+	if !ValidRID16Lenient("ABCD EFGH-IJKL MNOP") || ValidRID20Lenient("ABCD-EFGH") {
+		t.Fatalf("lenient validation mismatch")
+	}
+}
+
+func Test_verifySignedRID(t *testing.T) {
+	var id = NewRID20Signed("secret")
+	if err := VerifySignedRID(id, "secret"); err != nil {
+		t.Fatalf("VerifySignedRID(%s) = %v\n", id, err)
+	}
+	for _, c := range []struct {
+		id  string
+		err error
+	}{
+		{id[:35], ErrBadLength},
+		{"-" + id[1:], ErrBadAlphabet},
+		{id, ErrBadSignature},
+	} {
+		var secret = "secret"
+		if c.err == ErrBadSignature {
+			secret = "other"
+		}
+		if err := VerifySignedRID(c.id, secret); err != c.err {
+			t.Fatalf("VerifySignedRID(%s) = %v, want %v\n", c.id, err, c.err)
+		}
+	}
+}
+
+func Test_signedB62(t *testing.T) {
+	var id = NewRID20SignedB62("secret")
+	if len(id) != 31 || !b62regexp.MatchString(id) || !ValidRID20SignedB62(id, "secret") {
+		t.Fatalf("invalid base62-signed RID %s\n", id)
+	}
+	if ValidRID20SignedB62(id, "other") || ValidRID20SignedB62(id[:30], "secret") {
+		t.Fatalf("base62-signed RID valid with wrong secret or length")
+	}
+	var g = New(WithSigner("secret"), WithBase62MAC(), WithMACLength(MAC128))
+	if id = g.NewID(); len(id) != 42 || !b62regexp.MatchString(id) || !g.Valid(id) {
+		t.Fatalf("invalid base62-signed ID %s\n", id)
+	}
+}
+
+func Test_signedAny(t *testing.T) {
+	var old = NewRID20Signed("previous")
+	if !ValidRID20SignedAny(old, "current", "previous") || ValidRID20SignedAny(old, "current") || ValidRID20SignedAny(old) {
+		t.Fatalf("ValidRID20SignedAny wrong for %s\n", old)
+	}
+	var k = NewKeyring()
+	k.Add("k2", []byte("current"))
+	k.Add("k1", []byte("previous"))
+	if !k.ValidRID20SignedAny(old) || k.ValidRID20SignedAny(NewRID20Signed("other")) {
+		t.Fatalf("Keyring.ValidRID20SignedAny wrong for %s\n", old)
+	}
+	k.Remove("k1")
+	if k.ValidRID20SignedAny(old) {
+		t.Fatalf("RID of removed key %s valid\n", old)
+	}
+}
+
+func Test_blindIndex(t *testing.T) {
+	var b = BlindIndex("alice@example.com", "index key", 16)
+	if len(b) != 16 || !b62regexp.MatchString(b) || b != BlindIndex("alice@example.com", "index key", 16) {
+		t.Fatalf("bad blind index %s\n", b)
+	}
+	if b == BlindIndex("alice@example.com", "other key", 16) || b == BlindIndex("bob@example.com", "index key", 16) {
+		t.Fatalf("blind index %s collides\n", b)
+	}
+	if full := BlindIndex("alice@example.com", "index key", 43); full[43-16:] != b {
+		t.Fatalf("blind index %s is not a truncation of %s\n", b, full)
+	}
+}
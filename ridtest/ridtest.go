@@ -0,0 +1,143 @@
+// Package ridtest provides ID generators for unit tests of code that depends on rid.
+package ridtest
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/seckiss/rid"
+)
+
+var (
+	_ rid.Source = (*SequenceGenerator)(nil)
+	_ rid.Source = (*FixedGenerator)(nil)
+	_ rid.Source = (*RecordingGenerator)(nil)
+)
+
+///////////////////////////////////////////////////////////////////////////
+// SequenceGenerator returns "ID000001", "ID000002", ...
+///////////////////////////////////////////////////////////////////////////
+
+type SequenceGenerator struct {
+	lk     sync.Mutex
+	prefix string
+	width  int
+	n      int
+}
+
+func NewSequence() *SequenceGenerator {
+	return NewSequenceWith("ID", 6)
+}
+
+// IDs are prefix followed by the counter zero-padded to width digits
+func NewSequenceWith(prefix string, width int) *SequenceGenerator {
+	return &SequenceGenerator{prefix: prefix, width: width}
+}
+
+func (s *SequenceGenerator) NewID() string {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.n++
+	return fmt.Sprintf("%s%0*d", s.prefix, s.width, s.n)
+}
+
+// The counter is zero-padded so that the whole ID has n characters
+func (s *SequenceGenerator) NewIDn(n int) (string, error) {
+	if n <= len(s.prefix) {
+		return "", fmt.Errorf("ridtest: length %d leaves no room after prefix %s", n, s.prefix)
+	}
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.n++
+	var id = fmt.Sprintf("%s%0*d", s.prefix, n-len(s.prefix), s.n)
+	if len(id) != n {
+		return "", fmt.Errorf("ridtest: sequence ID %s does not fit in %d characters", id, n)
+	}
+	return id, nil
+}
+
+// Reset restarts the sequence from 1
+func (s *SequenceGenerator) Reset() {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.n = 0
+}
+
+///////////////////////////////////////////////////////////////////////////
+// FixedGenerator always returns the same ID
+///////////////////////////////////////////////////////////////////////////
+
+type FixedGenerator struct {
+	ID string
+}
+
+func NewFixed(id string) *FixedGenerator {
+	return &FixedGenerator{ID: id}
+}
+
+func (f *FixedGenerator) NewID() string {
+	return f.ID
+}
+
+// Fails unless the fixed ID has n characters, which catches tests asking for the wrong length
+func (f *FixedGenerator) NewIDn(n int) (string, error) {
+	if len(f.ID) != n {
+		return "", fmt.Errorf("ridtest: fixed ID %s has length %d, requested %d", f.ID, len(f.ID), n)
+	}
+	return f.ID, nil
+}
+
+///////////////////////////////////////////////////////////////////////////
+// RecordingGenerator captures every ID issued by the wrapped generator
+///////////////////////////////////////////////////////////////////////////
+
+type RecordingGenerator struct {
+	lk  sync.Mutex
+	g   rid.Source
+	ids []string
+}
+
+// Record wraps g, nil records RID20s from rid.New()
+func Record(g rid.Source) *RecordingGenerator {
+	if g == nil {
+		g = rid.New()
+	}
+	return &RecordingGenerator{g: g}
+}
+
+func (r *RecordingGenerator) NewID() string {
+	return r.record(r.g.NewID())
+}
+
+// Failed calls are not recorded
+func (r *RecordingGenerator) NewIDn(n int) (string, error) {
+	id, err := r.g.NewIDn(n)
+	if err != nil {
+		return "", err
+	}
+	return r.record(id), nil
+}
+
+func (r *RecordingGenerator) record(id string) string {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	r.ids = append(r.ids, id)
+	return id
+}
+
+// IDs returns a copy of the issued IDs in order
+func (r *RecordingGenerator) IDs() []string {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	return append([]string(nil), r.ids...)
+}
+
+// Last returns the most recently issued ID or "" if there is none
+func (r *RecordingGenerator) Last() string {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	if len(r.ids) == 0 {
+		return ""
+	}
+	return r.ids[len(r.ids)-1]
+}
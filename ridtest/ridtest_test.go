@@ -0,0 +1,45 @@
+package ridtest
+
+import (
+	"testing"
+
+	"github.com/seckiss/rid"
+)
+
+func Test_sequence(t *testing.T) {
+	var s = NewSequence()
+	if a, b := s.NewID(), s.NewID(); a != "ID000001" || b != "ID000002" {
+		t.Fatalf("unexpected sequence: %s, %s\n", a, b)
+	}
+	s.Reset()
+	if a := s.NewID(); a != "ID000001" {
+		t.Fatalf("sequence not reset: %s\n", a)
+	}
+}
+
+func Test_recording(t *testing.T) {
+	var r = Record(NewFixed("abc"))
+	r.NewID()
+	r.NewID()
+	if ids := r.IDs(); len(ids) != 2 || ids[1] != "abc" || r.Last() != "abc" {
+		t.Fatalf("unexpected recorded ids: %v\n", ids)
+	}
+	var d = Record(nil)
+	if id := d.NewID(); !rid.ValidRID20(id) || d.Last() != id {
+		t.Fatalf("unexpected default recorded id: %s\n", id)
+	}
+}
+
+func Test_sourceLength(t *testing.T) {
+	if id, err := NewSequence().NewIDn(10); err != nil || id != "ID00000001" {
+		t.Fatalf("unexpected sequence id: %s, %v\n", id, err)
+	}
+	if _, err := NewFixed("abc").NewIDn(4); err == nil {
+		t.Fatalf("fixed generator should reject wrong length")
+	}
+	var r = Record(NewFixed("abc"))
+	r.NewIDn(4)
+	if len(r.IDs()) != 0 {
+		t.Fatalf("failed call should not be recorded")
+	}
+}
@@ -0,0 +1,259 @@
+package rid
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Composite IDs declared as a Schema of parts, compiled into a generator and a parser.
+// Generated parts use Base62SortableAlphabet, so IDs with a leading timestamp sort by time.
+///////////////////////////////////////////////////////////////////////////
+
+type Schema []Part
+
+// Part is one component of a Schema, see Prefix, Timestamp, Random, Digits and Checksum
+type Part struct {
+	kind  partKind
+	name  string
+	lit   string
+	width int
+	unit  time.Duration
+	check CheckFunc
+}
+
+type partKind int
+
+const (
+	prefixPart partKind = iota
+	timestampPart
+	randomPart
+	digitsPart
+	checksumPart
+)
+
+// CheckFunc returns the check chars of everything before the Checksum part, always the same number of them
+type CheckFunc func(s string) string
+
+var ErrInvalidSchemaID = errors.New("rid: ID does not match schema")
+
+var ErrSchemaTimeRange = errors.New("rid: time out of the range of the schema timestamp")
+
+// timestamps cover at least the years 1970 to 3000
+var schemaTimeEnd = time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Prefix is the literal s, named "prefix"
+func Prefix(s string) Part {
+	return Part{kind: prefixPart, name: "prefix", lit: s, width: len(s)}
+}
+
+// Timestamp is the time in whole units since the Unix epoch, named "timestamp".
+// It takes as many chars as the unit needs up to the year 3000, e.g. 8 for milliseconds (about 6900 years),
+// 6 for seconds (about 1800 years) and 10 for microseconds.
+func Timestamp(unit time.Duration) Part {
+	var width = 0
+	if unit > 0 {
+		var end = unitsSinceEpoch(schemaTimeEnd, unit)
+		for width = 1; end.Cmp(big.NewInt(62)) >= 0; width++ {
+			end.Div(end, big.NewInt(62))
+		}
+	}
+	return Part{kind: timestampPart, name: "timestamp", unit: unit, width: width}
+}
+
+// whole units of t since the Unix epoch, without the overflow of UnixNano beyond 2262
+func unitsSinceEpoch(t time.Time, unit time.Duration) *big.Int {
+	var ns = new(big.Int).Mul(big.NewInt(t.Unix()), big.NewInt(int64(time.Second)))
+	ns.Add(ns, big.NewInt(int64(t.Nanosecond())))
+	return ns.Quo(ns, big.NewInt(int64(unit)))
+}
+
+// Random is n random chars, named "random"
+func Random(n int) Part {
+	return Part{kind: randomPart, name: "random", width: n}
+}
+
+// Digits is n random decimal digits, named "digits", for numeric IDs such as account numbers
+func Digits(n int) Part {
+	return Part{kind: digitsPart, name: "digits", width: n}
+}
+
+// Checksum appends the output of check over all preceding chars, named "checksum", e.g. Checksum(Luhn62)
+// or Checksum(Damm10) for numeric IDs
+func Checksum(check CheckFunc) Part {
+	return Part{kind: checksumPart, name: "checksum", check: check, width: len(check(""))}
+}
+
+// Named renames a part, to tell apart several parts of the same kind
+func (p Part) Named(name string) Part {
+	p.name = name
+	return p
+}
+
+// SchemaID generates and parses IDs of a compiled Schema
+type SchemaID struct {
+	parts  []Part
+	length int
+}
+
+func (s Schema) Compile() (*SchemaID, error) {
+	var c = &SchemaID{parts: s}
+	var names = make(map[string]bool)
+	for i, p := range s {
+		if names[p.name] {
+			return nil, fmt.Errorf("rid: duplicate schema part %q, see Named", p.name)
+		}
+		names[p.name] = true
+		switch {
+		case p.kind == timestampPart && p.unit <= 0:
+			return nil, fmt.Errorf("rid: invalid timestamp unit %v", p.unit)
+		case (p.kind == randomPart || p.kind == digitsPart) && p.width < 1:
+			return nil, fmt.Errorf("rid: invalid %s length %d", p.name, p.width)
+		case p.kind == checksumPart && (i != len(s)-1 || p.width < 1):
+			return nil, errors.New("rid: checksum must be the last part and produce at least one char")
+		}
+		c.length += p.width
+	}
+	return c, nil
+}
+
+// MustCompile is like Compile but panics on invalid schema
+func (s Schema) MustCompile() *SchemaID {
+	c, err := s.Compile()
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func (c *SchemaID) New() string {
+	id, err := c.NewAt(time.Now())
+	if err != nil {
+		//severe error - looks like a failure of random number generator
+		log.Fatal(err)
+	}
+	return id
+}
+
+func (c *SchemaID) NewAt(t time.Time) (string, error) {
+	var b = make([]byte, 0, c.length)
+	for _, p := range c.parts {
+		switch p.kind {
+		case prefixPart:
+			b = append(b, p.lit...)
+		case timestampPart:
+			if t.Before(time.Unix(0, 0)) {
+				return "", ErrSchemaTimeRange
+			}
+			s, ok := intToDigits(unitsSinceEpoch(t, p.unit), Base62SortableAlphabet, p.width)
+			if !ok {
+				return "", ErrSchemaTimeRange
+			}
+			b = append(b, s...)
+		case randomPart:
+			var err error
+			if b, err = appendAlphabet(b, packageReader(), base62Sortable, p.width); err != nil {
+				return "", err
+			}
+		case digitsPart:
+			var err error
+			if b, err = appendAlphabet(b, packageReader(), decimalDigits, p.width); err != nil {
+				return "", err
+			}
+		case checksumPart:
+			b = append(b, p.check(string(b))...)
+		}
+	}
+	return string(b), nil
+}
+
+// Components of a parsed ID, by part name
+type Components struct {
+	Parts map[string]string
+	// the first Timestamp part
+	Time time.Time
+}
+
+func (c *SchemaID) Parse(id string) (Components, error) {
+	if len(id) != c.length {
+		return Components{}, ErrInvalidSchemaID
+	}
+	var comp = Components{Parts: make(map[string]string, len(c.parts))}
+	var timeSet bool
+	var rest = id
+	for _, p := range c.parts {
+		var s = rest[:p.width]
+		rest = rest[p.width:]
+		switch p.kind {
+		case prefixPart:
+			if s != p.lit {
+				return Components{}, ErrInvalidSchemaID
+			}
+		case timestampPart:
+			v, ok := digitsToInt(s, Base62SortableAlphabet)
+			if !ok {
+				return Components{}, ErrInvalidSchemaID
+			}
+			if !timeSet {
+				var ns = new(big.Int)
+				v.Mul(v, big.NewInt(int64(p.unit))).QuoRem(v, big.NewInt(int64(time.Second)), ns)
+				comp.Time, timeSet = time.Unix(v.Int64(), ns.Int64()), true
+			}
+		case randomPart:
+			for i := 0; i < len(s); i++ {
+				if sortableIndex(s[i]) < 0 {
+					return Components{}, ErrInvalidSchemaID
+				}
+			}
+		case digitsPart:
+			for i := 0; i < len(s); i++ {
+				if s[i] < '0' || s[i] > '9' {
+					return Components{}, ErrInvalidSchemaID
+				}
+			}
+		case checksumPart:
+			if s != p.check(id[:len(id)-p.width]) {
+				return Components{}, ErrInvalidSchemaID
+			}
+		}
+		comp.Parts[p.name] = s
+	}
+	return comp, nil
+}
+
+func (c *SchemaID) Valid(id string) bool {
+	_, err := c.Parse(id)
+	return err == nil
+}
+
+// Luhn62 is the Luhn mod N algorithm over Base62SortableAlphabet, one check char that catches
+// every single-char error. It is weaker than Damm on transpositions: it misses the swap of adjacent 0 and z
+// and every jump transposition such as abc to cba. Chars outside the alphabet count by their byte value.
+func Luhn62(s string) string {
+	return string(luhnModN(s, Base62SortableAlphabet))
+}
+
+// Damm10 is the Damm check digit for numeric IDs, see Digits. It catches every single-digit error
+// and every adjacent transposition, but it skips all chars other than digits, so it doesn't protect letters.
+func Damm10(s string) string {
+	return string(Damm(s))
+}
+
+func luhnModN(s string, alphabet string) byte {
+	var n = len(alphabet)
+	var sum, factor = 0, 2
+	for i := len(s) - 1; i >= 0; i-- {
+		var d = strings.IndexByte(alphabet, s[i])
+		if d < 0 {
+			d = int(s[i]) % n
+		}
+		var addend = factor * d
+		sum += addend/n + addend%n
+		factor = 3 - factor
+	}
+	return alphabet[(n-sum%n)%n]
+}
@@ -0,0 +1,98 @@
+package rid
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_schema(t *testing.T) {
+	var s = Schema{Prefix("ord_"), Timestamp(time.Millisecond), Random(10), Checksum(Luhn62)}.MustCompile()
+	var now = time.UnixMilli(1700000000123)
+	id, err := s.NewAt(now)
+	if err != nil || len(id) != 23 || !s.Valid(id) {
+		t.Fatalf("invalid schema ID %s, %v\n", id, err)
+	}
+	c, err := s.Parse(id)
+	if err != nil || !c.Time.Equal(now) || c.Parts["prefix"] != "ord_" || c.Parts["random"] != id[12:22] || c.Parts["checksum"] != id[22:] {
+		t.Fatalf("Parse(%s) = %v, %v\n", id, c, err)
+	}
+	// every single-char substitution is caught by the check char
+	for i := 4; i < 22; i++ {
+		var b = []byte(id)
+		b[i] = Base62SortableAlphabet[(sortableIndex(b[i])+1)%62]
+		if s.Valid(string(b)) {
+			t.Fatalf("typo at %d not detected: %s\n", i, b)
+		}
+	}
+	if _, err = (Schema{Random(4), Random(4)}).Compile(); err == nil {
+		t.Fatalf("duplicate part names accepted")
+	}
+	if _, err = (Schema{Random(4), Random(4).Named("more")}).Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = (Schema{Checksum(Luhn62), Random(4)}).Compile(); err == nil {
+		t.Fatalf("checksum before the end accepted")
+	}
+}
+
+func Test_schemaDamm(t *testing.T) {
+	var s = Schema{Prefix("7"), Digits(9), Checksum(Damm10)}.MustCompile()
+	var id = s.New()
+	c, err := s.Parse(id)
+	if err != nil || len(id) != 11 || c.Parts["digits"] != id[1:10] {
+		t.Fatalf("Parse(%s) = %v, %v\n", id, c, err)
+	}
+	// every adjacent transposition of different digits is caught
+	for i := 0; i < 10; i++ {
+		var b = []byte(id)
+		if b[i] == b[i+1] {
+			continue
+		}
+		b[i], b[i+1] = b[i+1], b[i]
+		if s.Valid(string(b)) {
+			t.Fatalf("transposition at %d not detected: %s\n", i, b)
+		}
+	}
+	if s.Valid(id[:1] + "a" + id[2:]) {
+		t.Fatalf("letter accepted in digits\n")
+	}
+	// the weakness of Luhn62
+	if Luhn62("ab0z") != Luhn62("abz0") {
+		t.Fatalf("Luhn62 unexpectedly catches 0z\n")
+	}
+}
+
+func Test_schemaTimestampUnits(t *testing.T) {
+	for _, c := range []struct {
+		unit  time.Duration
+		width int
+	}{
+		{time.Nanosecond, 11},
+		{time.Microsecond, 10},
+		{time.Millisecond, 8},
+		{time.Second, 6},
+		{time.Hour, 4},
+	} {
+		var p = Timestamp(c.unit)
+		if p.width != c.width {
+			t.Fatalf("Timestamp(%v) width %d, want %d\n", c.unit, p.width, c.width)
+		}
+		var s = Schema{p, Random(4)}.MustCompile()
+		var times = []time.Time{time.Unix(0, 0), time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC), time.Date(2999, 12, 31, 23, 0, 0, 0, time.UTC)}
+		var prev string
+		for _, tm := range times {
+			id, err := s.NewAt(tm)
+			if err != nil || id <= prev {
+				t.Fatalf("NewAt(%v) = %s, %v after %s\n", tm, id, err, prev)
+			}
+			prev = id
+			c, err := s.Parse(id)
+			if err != nil || !c.Time.Equal(tm.Truncate(p.unit)) {
+				t.Fatalf("Parse(%s) = %v, %v, want %v\n", id, c.Time, err, tm)
+			}
+		}
+		if _, err := s.NewAt(time.Unix(-1, 0)); err != ErrSchemaTimeRange {
+			t.Fatalf("time before 1970 gave %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package rid
+
+import (
+	"crypto/hmac"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Versioned signed RIDs: a scheme version character, RID20 and the MAC of both.
+// The version selects MAC algorithm, truncation and encoding, so the default scheme can change
+// while IDs of older schemes keep verifying.
+///////////////////////////////////////////////////////////////////////////
+
+// SignatureScheme signs the version character and the RID with secret, in Length characters
+type SignatureScheme struct {
+	// a base62 character, unique among registered schemes
+	Version byte
+	Name    string
+	Length  int
+	Sign    func(message string, secret string) string
+	// FIPS-approved, in FIPS mode other schemes neither sign nor verify
+	Approved bool
+}
+
+var (
+	// the MAC of NewRID20Signed
+	SchemeV1 = &SignatureScheme{Version: '1', Name: "HMAC-SHA256/64 hex", Length: 16, Sign: HMAC, Approved: true}
+	SchemeV2 = &SignatureScheme{Version: '2', Name: "HMAC-SHA256/128 base62", Length: 22, Sign: func(message string, secret string) string {
+		return HMACBase62(message, secret, MAC128)
+	}, Approved: true}
+)
+
+// scheme of NewRID20SignedVersioned
+var DefaultSignatureScheme = SchemeV2
+
+var signatureSchemes = struct {
+	lk sync.RWMutex
+	m  map[byte]*SignatureScheme
+}{m: map[byte]*SignatureScheme{'1': SchemeV1, '2': SchemeV2}}
+
+func RegisterSignatureScheme(s *SignatureScheme) {
+	if strings.IndexByte(Base62Alphabet, s.Version) < 0 {
+		panic(fmt.Sprintf("rid: signature scheme version %q is not base62", s.Version))
+	}
+	signatureSchemes.lk.Lock()
+	defer signatureSchemes.lk.Unlock()
+	if _, dup := signatureSchemes.m[s.Version]; dup {
+		panic(fmt.Sprintf("rid: signature scheme version %q registered twice", s.Version))
+	}
+	signatureSchemes.m[s.Version] = s
+}
+
+func lookupSignatureScheme(version byte) *SignatureScheme {
+	signatureSchemes.lk.RLock()
+	defer signatureSchemes.lk.RUnlock()
+	return signatureSchemes.m[version]
+}
+
+func NewRID20SignedVersioned(secret string) string {
+	return NewRID20SignedScheme(DefaultSignatureScheme, secret)
+}
+
+// Panics with ErrNotFIPSApproved for a scheme not approved in FIPS mode
+func NewRID20SignedScheme(s *SignatureScheme, secret string) string {
+	if !s.Approved && FIPSMode() {
+		panic(ErrNotFIPSApproved)
+	}
+	var r = string(s.Version) + NewRID20()
+	return r + s.Sign(r, secret)
+}
+
+// VerifySignedRIDVersioned checks an ID of any registered scheme.
+// The version is signed too, so an ID can't be downgraded to a weaker scheme.
+// In FIPS mode IDs of schemes not approved fail with ErrNotFIPSApproved.
+func VerifySignedRIDVersioned(r string, secret string) error {
+	if len(r) < 21 {
+		return ErrBadLength
+	}
+	var s = lookupSignatureScheme(r[0])
+	if s == nil {
+		return ErrBadSignature
+	}
+	if !s.Approved && FIPSMode() {
+		return ErrNotFIPSApproved
+	}
+	if len(r) != 21+s.Length {
+		return ErrBadLength
+	}
+	if !ValidRID20(r[1:21]) {
+		return ErrBadAlphabet
+	}
+	if !hmac.Equal([]byte(r[21:]), []byte(s.Sign(r[:21], secret))) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+func ValidRID20SignedVersioned(r string, secret string) bool {
+	return VerifySignedRIDVersioned(r, secret) == nil
+}
@@ -0,0 +1,43 @@
+package rid
+
+import (
+	"testing"
+)
+
+func Test_RID20SignedVersioned(t *testing.T) {
+	var r = NewRID20SignedVersioned("secret")
+	if len(r) != 43 || r[0] != '2' || !b62regexp.MatchString(r) {
+		t.Fatalf("bad versioned RID %s\n", r)
+	}
+	var old = NewRID20SignedScheme(SchemeV1, "secret")
+	if len(old) != 37 || old[0] != '1' {
+		t.Fatalf("bad v1 RID %s\n", old)
+	}
+	for _, c := range []struct {
+		id     string
+		secret string
+		err    error
+	}{
+		{r, "secret", nil},
+		{old, "secret", nil},
+		{r, "other", ErrBadSignature},
+		{"1" + r[1:37], "secret", ErrBadSignature},
+		{"9" + r[1:], "secret", ErrBadSignature},
+		{r[:42], "secret", ErrBadLength},
+		{r[:1] + "-" + r[2:], "secret", ErrBadAlphabet},
+	} {
+		if err := VerifySignedRIDVersioned(c.id, c.secret); err != c.err {
+			t.Fatalf("VerifySignedRIDVersioned(%s) = %v, want %v\n", c.id, err, c.err)
+		}
+	}
+
+	var v3 = &SignatureScheme{Version: '3', Name: "HMAC-SHA256/256 hex", Length: 64, Sign: func(m, s string) string {
+		return HMACn(m, s, MAC256)
+	}}
+	if lookupSignatureScheme('3') == nil {
+		RegisterSignatureScheme(v3)
+	}
+	if r = NewRID20SignedScheme(v3, "secret"); !ValidRID20SignedVersioned(r, "secret") {
+		t.Fatalf("registered scheme RID %s invalid\n", r)
+	}
+}
@@ -0,0 +1,182 @@
+package rid
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Sequence IDs, a persisted counter followed by random chars. Ordered and surviving restarts,
+// with gaps where reserved blocks were not used up.
+///////////////////////////////////////////////////////////////////////////
+
+// SequenceStore reserves n consecutive counter values and returns the first one.
+// Values must never be handed out twice, also across restarts.
+type SequenceStore interface {
+	Reserve(n uint64) (uint64, error)
+}
+
+// SequenceStoreFunc adapts a function to SequenceStore, e.g. for Redis:
+//
+//	rid.SequenceStoreFunc(func(n uint64) (uint64, error) {
+//		end, err := rdb.IncrBy(ctx, "seq:orders", int64(n)).Result()
+//		return uint64(end) - n + 1, err
+//	})
+type SequenceStoreFunc func(n uint64) (uint64, error)
+
+func (f SequenceStoreFunc) Reserve(n uint64) (uint64, error) {
+	return f(n)
+}
+
+var _ Source = (*BlockSequenceGenerator)(nil)
+
+// BlockSequenceGenerator reserves counter values in blocks, so the store is hit once per block.
+// IDs are the counter in width chars of Base62SortableAlphabet followed by random chars of the same alphabet,
+// so they sort by counter.
+type BlockSequenceGenerator struct {
+	lk     sync.Mutex
+	store  SequenceStore
+	block  uint64
+	width  int
+	random int
+	next   uint64
+	end    uint64
+}
+
+// NewBlockSequenceGenerator makes IDs of width counter chars and random chars, 8 counter chars last for 2.1e14 IDs
+func NewBlockSequenceGenerator(store SequenceStore, block uint64, width, random int) *BlockSequenceGenerator {
+	if block < 1 || width < 1 || random < 0 {
+		panic(fmt.Sprintf("rid: invalid sequence block %d, width %d or random %d", block, width, random))
+	}
+	return &BlockSequenceGenerator{store: store, block: block, width: width, random: random}
+}
+
+func (g *BlockSequenceGenerator) NewID() string {
+	id, err := g.NewIDn(g.width + g.random)
+	if err != nil {
+		//severe error - the store or the random number generator failed
+		log.Fatal(err)
+	}
+	return id
+}
+
+// As NewID with n chars in total, the counter and n-width random chars, and the store failure returned to the caller
+func (g *BlockSequenceGenerator) NewIDn(n int) (string, error) {
+	if n < g.width {
+		return "", fmt.Errorf("rid: length %d leaves no room for %d counter chars", n, g.width)
+	}
+	v, err := g.nextValue()
+	if err != nil {
+		return "", err
+	}
+	var b = make([]byte, g.width, n)
+	for i := g.width - 1; i >= 0; i-- {
+		b[i] = Base62SortableAlphabet[v%62]
+		v /= 62
+	}
+	if v != 0 {
+		return "", errors.New("rid: sequence counter exceeds the width")
+	}
+	b, err = appendAlphabet(b, packageReader(), base62Sortable, n-g.width)
+	return string(b), err
+}
+
+func (g *BlockSequenceGenerator) nextValue() (uint64, error) {
+	g.lk.Lock()
+	defer g.lk.Unlock()
+	if g.next == g.end {
+		first, err := g.store.Reserve(g.block)
+		if err != nil {
+			return 0, err
+		}
+		g.next, g.end = first, first+g.block
+	}
+	g.next++
+	return g.next - 1, nil
+}
+
+// SequenceOf returns the counter of a sequence ID of the given counter width
+func SequenceOf(id string, width int) (uint64, error) {
+	if len(id) < width {
+		return 0, ErrInvalidRID
+	}
+	var v uint64
+	for i := 0; i < width; i++ {
+		var d = sortableIndex(id[i])
+		if d < 0 {
+			return 0, ErrInvalidRID
+		}
+		v = v*62 + uint64(d)
+	}
+	return v, nil
+}
+
+// FileSequenceStore keeps the end of the last reserved block in a file, replaced atomically on every reservation.
+// It is safe for the goroutines of one process, not for several processes sharing the file.
+type FileSequenceStore struct {
+	lk   sync.Mutex
+	path string
+}
+
+func NewFileSequenceStore(path string) *FileSequenceStore {
+	return &FileSequenceStore{path: path}
+}
+
+func (s *FileSequenceStore) Reserve(n uint64) (uint64, error) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	var last uint64
+	b, err := os.ReadFile(s.path)
+	if err == nil {
+		if last, err = strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64); err != nil {
+			return 0, fmt.Errorf("rid: corrupt sequence file %s: %w", s.path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return 0, err
+	}
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.WriteString(strconv.FormatUint(last+n, 10) + "\n"); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), s.path)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return last + 1, nil
+}
+
+// SQLSequenceStore runs a statement that adds its single argument to a counter row and returns the new value, e.g.
+//
+//	UPDATE sequences SET value = value + $1 WHERE name = 'orders' RETURNING value
+type SQLSequenceStore struct {
+	db      *sql.DB
+	reserve string
+}
+
+func NewSQLSequenceStore(db *sql.DB, reserve string) *SQLSequenceStore {
+	return &SQLSequenceStore{db: db, reserve: reserve}
+}
+
+func (s *SQLSequenceStore) Reserve(n uint64) (uint64, error) {
+	var end uint64
+	if err := s.db.QueryRow(s.reserve, n).Scan(&end); err != nil {
+		return 0, err
+	}
+	return end - n + 1, nil
+}
@@ -0,0 +1,47 @@
+package rid
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func Test_sequenceGenerator(t *testing.T) {
+	var path = filepath.Join(t.TempDir(), "seq")
+	var g = NewBlockSequenceGenerator(NewFileSequenceStore(path), 10, 6, 8)
+	var prev string
+	for i := 1; i <= 25; i++ {
+		var id = g.NewID()
+		if len(id) != 14 || id <= prev {
+			t.Fatalf("sequence IDs not increasing: %s <= %s\n", id, prev)
+		}
+		if v, err := SequenceOf(id, 6); err != nil || v != uint64(i) {
+			t.Fatalf("SequenceOf(%s) = %d, %v, want %d\n", id, v, err, i)
+		}
+		prev = id
+	}
+	if id, err := g.NewIDn(10); err != nil || len(id) != 10 {
+		t.Fatalf("unexpected ID %s, %v\n", id, err)
+	}
+	// a restart continues after the reserved block 21..30
+	g = NewBlockSequenceGenerator(NewFileSequenceStore(path), 10, 6, 8)
+	if v, _ := SequenceOf(g.NewID(), 6); v != 31 {
+		t.Fatalf("unexpected counter %d after restart\n", v)
+	}
+}
+
+func Test_sequenceStoreFunc(t *testing.T) {
+	var end uint64 = 60
+	var g = NewBlockSequenceGenerator(SequenceStoreFunc(func(n uint64) (uint64, error) {
+		end += n
+		return end - n + 1, nil
+	}), 1, 1, 0)
+	if id := g.NewID(); id != "z" {
+		t.Fatalf("unexpected ID %s\n", id)
+	}
+	if _, err := g.NewIDn(1); err == nil {
+		t.Fatalf("counter beyond the width accepted")
+	}
+	if _, err := g.NewIDn(-1); err == nil {
+		t.Fatalf("negative length accepted")
+	}
+}
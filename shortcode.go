@@ -0,0 +1,36 @@
+package rid
+
+import (
+	"fmt"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Short codes checked for uniqueness by the caller, as for URL shorteners
+///////////////////////////////////////////////////////////////////////////
+
+// collisions tolerated at one length before the code grows
+const shortCodeAttempts = 3
+
+// NewUniqueShortCode returns a base62 code of at least n chars for which exists reports false.
+// After 3 collisions at one length the length grows by half, so a nearly full code space
+// costs a few more chars instead of endless lookups. Errors of exists are returned as they are.
+// Unless exists reserves the code atomically, the caller must still handle a duplicate key on insert.
+func NewUniqueShortCode(n int, exists func(string) (bool, error)) (string, error) {
+	if n < 1 {
+		panic(fmt.Sprintf("rid: invalid length %d", n))
+	}
+	for grow := 0; grow < 10; grow++ {
+		for i := 0; i < shortCodeAttempts; i++ {
+			var code = NewRIDn(n)
+			taken, err := exists(code)
+			if err != nil {
+				return "", err
+			}
+			if !taken {
+				return code, nil
+			}
+		}
+		n += (n + 1) / 2
+	}
+	return "", ErrTooManyRetries
+}
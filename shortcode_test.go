@@ -0,0 +1,25 @@
+package rid
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_uniqueShortCode(t *testing.T) {
+	// every code of 4 chars is taken
+	var calls int
+	code, err := NewUniqueShortCode(4, func(s string) (bool, error) {
+		calls++
+		return len(s) == 4, nil
+	})
+	if err != nil || len(code) != 6 || calls != 4 {
+		t.Fatalf("NewUniqueShortCode = %s, %v after %d calls\n", code, err, calls)
+	}
+	var dbErr = errors.New("db down")
+	if _, err = NewUniqueShortCode(4, func(string) (bool, error) { return false, dbErr }); err != dbErr {
+		t.Fatalf("lookup error not returned: %v\n", err)
+	}
+	if _, err = NewUniqueShortCode(1, func(string) (bool, error) { return true, nil }); err != ErrTooManyRetries {
+		t.Fatalf("expected ErrTooManyRetries, got %v\n", err)
+	}
+}
@@ -0,0 +1,102 @@
+package rid
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// External signing, for MAC keys held in a KMS or HSM instead of process memory.
+// IDs are 20 base62 characters, the key ID and the 32-byte MAC of a label and the 20 characters in 43 base62 characters.
+// The label keeps the MAC apart from other HMACs of the same key, e.g. of NewRID20Signed.
+// The key ID is not signed since the signer picks it while signing, a changed key ID selects another key and fails.
+///////////////////////////////////////////////////////////////////////////
+
+// Signer computes a 32-byte MAC, e.g. HMAC-SHA256, with its current key
+type Signer interface {
+	Sign(ctx context.Context, message []byte) (keyID string, mac []byte, err error)
+}
+
+// Verifier checks a MAC of Signer with the key named keyID. It returns ErrBadSignature for a wrong MAC,
+// other errors are failures of the key store.
+type Verifier interface {
+	Verify(ctx context.Context, keyID string, message, mac []byte) error
+}
+
+// Packages awskms and gcpkms implement both for AWS KMS and Google Cloud KMS MAC keys.
+// They are separate so that only their importers depend on the vendor SDKs.
+
+// 43 base62 characters of a 32-byte MAC
+const externalMACChars = 43
+
+// prefixed to the RID in the signed message
+const signedWithLabel = "rid signed with"
+
+// NewRIDSignedWith signs a new RID20 with s
+func NewRIDSignedWith(ctx context.Context, s Signer) (string, error) {
+	r := NewRID20()
+	keyID, mac, err := s.Sign(ctx, []byte(signedWithLabel+r))
+	if err != nil {
+		return "", err
+	}
+	if len(keyID) < 1 || len(keyID) > maxKeyIDLength || !b62regexp.MatchString(keyID) || len(mac) != sha256.Size {
+		return "", fmt.Errorf("rid: signer returned key ID %q and %d-byte MAC", keyID, len(mac))
+	}
+	s62, _ := intToDigits(new(big.Int).SetBytes(mac), Base62Alphabet, externalMACChars)
+	return r + keyID + s62, nil
+}
+
+// VerifyRIDSignedWith checks an ID of NewRIDSignedWith. The MAC covers the label and the RID20, the key ID selects the key.
+func VerifyRIDSignedWith(ctx context.Context, r string, v Verifier) error {
+	var n = len(r) - 20 - externalMACChars
+	if n < 1 || n > maxKeyIDLength {
+		return ErrBadLength
+	}
+	if !b62regexp.MatchString(r) {
+		return ErrBadAlphabet
+	}
+	x, _ := digitsToInt(r[20+n:], Base62Alphabet)
+	if x.BitLen() > 8*sha256.Size {
+		return ErrBadSignature
+	}
+	var mac = make([]byte, sha256.Size)
+	x.FillBytes(mac)
+	return v.Verify(ctx, r[20:20+n], []byte(signedWithLabel+r[:20]), mac)
+}
+
+// KeyProviderSigner signs and verifies in process with HMAC-SHA256 keys of a KeyProvider,
+// for development and for tests of code written against Signer and Verifier
+type KeyProviderSigner struct {
+	Keys KeyProvider
+}
+
+var (
+	_ Signer   = KeyProviderSigner{}
+	_ Verifier = KeyProviderSigner{}
+)
+
+func (s KeyProviderSigner) Sign(_ context.Context, message []byte) (string, []byte, error) {
+	id, key := s.Keys.CurrentKey()
+	if key == nil {
+		return "", nil, ErrNoCurrentKey
+	}
+	var mac = hmac.New(sha256.New, key)
+	mac.Write(message)
+	return id, mac.Sum(nil), nil
+}
+
+func (s KeyProviderSigner) Verify(_ context.Context, keyID string, message, sum []byte) error {
+	key, ok := s.Keys.KeyByID(keyID)
+	if !ok {
+		return ErrBadSignature
+	}
+	var mac = hmac.New(sha256.New, key)
+	mac.Write(message)
+	if !hmac.Equal(mac.Sum(nil), sum) {
+		return ErrBadSignature
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package rid
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+)
+
+func Test_externalSigner(t *testing.T) {
+	var ctx = context.Background()
+	var k = NewKeyring()
+	k.Add("kms1", []byte("held by the KMS"))
+	var s = KeyProviderSigner{Keys: k}
+	id, err := NewRIDSignedWith(ctx, s)
+	if err != nil || len(id) != 20+4+43 || !b62regexp.MatchString(id) {
+		t.Fatalf("NewRIDSignedWith = %s, %v\n", id, err)
+	}
+	if err = VerifyRIDSignedWith(ctx, id, s); err != nil {
+		t.Fatalf("VerifyRIDSignedWith(%s) = %v\n", id, err)
+	}
+	// the truncated MAC is not that of a legacy signed ID of the same key
+	x, _ := digitsToInt(id[24:], Base62Alphabet)
+	var mac = make([]byte, 32)
+	x.FillBytes(mac)
+	if legacy := id[:20] + hex.EncodeToString(mac[:MAC64]); k.ValidRID20SignedAny(legacy) {
+		t.Fatalf("legacy signed ID %s derived from %s\n", legacy, id)
+	}
+	var other = NewKeyring()
+	other.Add("kms1", []byte("another key"))
+	for _, c := range []struct {
+		id  string
+		v   Verifier
+		err error
+	}{
+		{id, KeyProviderSigner{Keys: other}, ErrBadSignature},
+		{id[:20] + "kms2" + id[24:], s, ErrBadSignature},
+		{id[:20] + id[24:], s, ErrBadLength},
+		{id[:20] + "km-1" + id[24:], s, ErrBadAlphabet},
+	} {
+		if err = VerifyRIDSignedWith(ctx, c.id, c.v); err != c.err {
+			t.Fatalf("VerifyRIDSignedWith(%s) = %v, want %v\n", c.id, err, c.err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package rid
+
+import (
+	"crypto/hmac"
+	"encoding/binary"
+	"encoding/hex"
+	"math/bits"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// SipHash-2-4 signed RIDs, for cache keys and internal request IDs where HMAC-SHA256 is too slow.
+// A 64-bit MAC with a 128-bit key, in the same shape as NewRID20Signed.
+///////////////////////////////////////////////////////////////////////////
+
+// NewRID20SignedSip returns 20 base62 characters followed by the hexed SipHash-2-4 of them.
+// SipHash is not FIPS-approved, it panics with ErrNotFIPSApproved in FIPS mode.
+func NewRID20SignedSip(key [16]byte) string {
+	r := NewRID20()
+	return r + sipHex(r, key)
+}
+
+// Panics with ErrNotFIPSApproved in FIPS mode, see NewRID20SignedSip
+func ValidRID20SignedSip(r string, key [16]byte) bool {
+	if len(r) != 36 || !ValidRID20(r[:20]) {
+		return false
+	}
+	return hmac.Equal([]byte(r[20:]), []byte(sipHex(r[:20], key)))
+}
+
+func sipHex(message string, key [16]byte) string {
+	if FIPSMode() {
+		panic(ErrNotFIPSApproved)
+	}
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], SipHash24(key, []byte(message)))
+	return hex.EncodeToString(b[:])
+}
+
+// SipHash24 is SipHash-2-4 of message under key
+func SipHash24(key [16]byte, message []byte) uint64 {
+	var k0, k1 = binary.LittleEndian.Uint64(key[:8]), binary.LittleEndian.Uint64(key[8:])
+	var v0, v1, v2, v3 = k0 ^ 0x736f6d6570736575, k1 ^ 0x646f72616e646f6d, k0 ^ 0x6c7967656e657261, k1 ^ 0x7465646279746573
+	var round = func() {
+		v0 += v1
+		v1 = bits.RotateLeft64(v1, 13) ^ v0
+		v0 = bits.RotateLeft64(v0, 32)
+		v2 += v3
+		v3 = bits.RotateLeft64(v3, 16) ^ v2
+		v0 += v3
+		v3 = bits.RotateLeft64(v3, 21) ^ v0
+		v2 += v1
+		v1 = bits.RotateLeft64(v1, 17) ^ v2
+		v2 = bits.RotateLeft64(v2, 32)
+	}
+	var n = len(message)
+	for ; len(message) >= 8; message = message[8:] {
+		var m = binary.LittleEndian.Uint64(message)
+		v3 ^= m
+		round()
+		round()
+		v0 ^= m
+	}
+	// last block: remaining bytes and the length in the top byte
+	var last = uint64(n) << 56
+	for i, c := range message {
+		last |= uint64(c) << (8 * i)
+	}
+	v3 ^= last
+	round()
+	round()
+	v0 ^= last
+	v2 ^= 0xff
+	round()
+	round()
+	round()
+	round()
+	return v0 ^ v1 ^ v2 ^ v3
+}
@@ -0,0 +1,34 @@
+package rid
+
+import (
+	"testing"
+)
+
+func Test_sipHash24(t *testing.T) {
+	// vectors of the SipHash paper: key 00..0f, message 00..(n-1)
+	var key [16]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	var message = make([]byte, 15)
+	for i := range message {
+		message[i] = byte(i)
+	}
+	if h := SipHash24(key, nil); h != 0x726fdb47dd0e0e31 {
+		t.Fatalf("SipHash24 of empty message = %x\n", h)
+	}
+	if h := SipHash24(key, message); h != 0xa129ca6149be45e5 {
+		t.Fatalf("SipHash24 of 15 bytes = %x\n", h)
+	}
+}
+
+func Test_signedSip(t *testing.T) {
+	var key = [16]byte{1, 2, 3}
+	var id = NewRID20SignedSip(key)
+	if len(id) != 36 || !ValidRID20SignedSip(id, key) {
+		t.Fatalf("invalid SipHash-signed RID %s\n", id)
+	}
+	if ValidRID20SignedSip(id, [16]byte{1, 2, 4}) || ValidRID20SignedSip(id[:35], key) {
+		t.Fatalf("SipHash-signed RID valid with wrong key or length")
+	}
+}
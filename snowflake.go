@@ -0,0 +1,114 @@
+package rid
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Snowflake, 64-bit IDs of millisecond timestamp, worker ID and sequence number
+///////////////////////////////////////////////////////////////////////////
+
+type SnowflakeConfig struct {
+	// timestamps count milliseconds from Epoch
+	Epoch        time.Time
+	WorkerBits   uint
+	SequenceBits uint
+	// must fit in WorkerBits
+	Worker int64
+}
+
+// Twitter's layout: 41 bits of time, 10 bits of worker, 12 bits of sequence
+var TwitterEpoch = time.UnixMilli(1288834974657)
+
+func TwitterSnowflakeConfig(worker int64) SnowflakeConfig {
+	return SnowflakeConfig{Epoch: TwitterEpoch, WorkerBits: 10, SequenceBits: 12, Worker: worker}
+}
+
+var ErrSnowflakeConfig = errors.New("rid: invalid snowflake config")
+
+// Snowflake generates strictly increasing IDs. When the sequence of a millisecond is exhausted,
+// or the clock goes back, it continues on a logical clock slightly ahead of the real one instead of blocking.
+type Snowflake struct {
+	lk       sync.Mutex
+	cfg      SnowflakeConfig
+	epochMs  int64
+	lastMs   int64
+	sequence int64
+}
+
+func NewSnowflake(cfg SnowflakeConfig) (*Snowflake, error) {
+	if cfg.WorkerBits+cfg.SequenceBits > 22 || cfg.Worker < 0 || cfg.Worker >= 1<<cfg.WorkerBits {
+		return nil, ErrSnowflakeConfig
+	}
+	return &Snowflake{cfg: cfg, epochMs: cfg.Epoch.UnixMilli(), lastMs: -1}, nil
+}
+
+func (s *Snowflake) Next() int64 {
+	return s.NextAt(time.Now())
+}
+
+func (s *Snowflake) NextAt(t time.Time) int64 {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	var ms = t.UnixMilli() - s.epochMs
+	if ms <= s.lastMs {
+		ms = s.lastMs
+		s.sequence++
+		if s.sequence == 1<<s.cfg.SequenceBits {
+			ms++
+			s.sequence = 0
+		}
+	} else {
+		s.sequence = 0
+	}
+	s.lastMs = ms
+	return ms<<(s.cfg.WorkerBits+s.cfg.SequenceBits) | s.cfg.Worker<<s.cfg.SequenceBits | s.sequence
+}
+
+// NextBase62 renders Next as 11 chars of Base62SortableAlphabet, which sort like the IDs
+func (s *Snowflake) NextBase62() string {
+	return FormatSnowflakeBase62(s.Next())
+}
+
+// Parse splits an ID of this generator's layout into its components
+func (s *Snowflake) Parse(id int64) (t time.Time, worker, sequence int64) {
+	return parseSnowflake(id, s.epochMs, s.cfg.WorkerBits, s.cfg.SequenceBits)
+}
+
+func parseSnowflake(id, epochMs int64, workerBits, sequenceBits uint) (t time.Time, worker, sequence int64) {
+	return time.UnixMilli(id>>(workerBits+sequenceBits) + epochMs),
+		id >> sequenceBits & (1<<workerBits - 1),
+		id & (1<<sequenceBits - 1)
+}
+
+// 2015-01-01T00:00:00Z
+var DiscordEpoch = time.UnixMilli(1420070400000)
+
+// ParseSnowflake splits a decimal snowflake of the common 10 worker and 12 sequence bits layout, as issued by Twitter and Discord.
+// The worker holds datacenter<<5|worker for Twitter and worker<<5|process for Discord.
+func ParseSnowflake(id string, epoch time.Time) (t time.Time, worker, sequence int64, err error) {
+	v, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || v < 0 {
+		return time.Time{}, 0, 0, fmt.Errorf("rid: invalid snowflake %q", id)
+	}
+	t, worker, sequence = parseSnowflake(v, epoch.UnixMilli(), 10, 12)
+	return t, worker, sequence, nil
+}
+
+func FormatSnowflakeBase62(id int64) string {
+	s, _ := intToDigits(big.NewInt(id), Base62SortableAlphabet, 11)
+	return s
+}
+
+func ParseSnowflakeBase62(s string) (int64, error) {
+	x, ok := digitsToInt(s, Base62SortableAlphabet)
+	if !ok || len(s) != 11 || !x.IsInt64() {
+		return 0, fmt.Errorf("rid: invalid base62 snowflake %q", s)
+	}
+	return x.Int64(), nil
+}
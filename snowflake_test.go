@@ -0,0 +1,50 @@
+package rid
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_snowflake(t *testing.T) {
+	s, err := NewSnowflake(SnowflakeConfig{Epoch: time.UnixMilli(0), WorkerBits: 4, SequenceBits: 2, Worker: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var now = time.UnixMilli(1000)
+	var prev int64 = -1
+	for i := 0; i < 10; i++ {
+		var id = s.NextAt(now)
+		if id <= prev {
+			t.Fatalf("snowflakes not increasing: %d <= %d\n", id, prev)
+		}
+		prev = id
+	}
+	// 4 IDs per millisecond, the 10th is on the logical clock 2 ms ahead
+	tm, worker, seq := s.Parse(prev)
+	if tm.UnixMilli() != 1002 || worker != 5 || seq != 1 {
+		t.Fatalf("unexpected components: %v %d %d\n", tm.UnixMilli(), worker, seq)
+	}
+	var b = FormatSnowflakeBase62(prev)
+	if back, err := ParseSnowflakeBase62(b); err != nil || back != prev || b >= FormatSnowflakeBase62(prev+1) {
+		t.Fatalf("base62 rendering failed: %s, %v\n", b, err)
+	}
+	if _, err = NewSnowflake(SnowflakeConfig{WorkerBits: 2, SequenceBits: 2, Worker: 4}); err != ErrSnowflakeConfig {
+		t.Fatalf("worker out of range should be rejected")
+	}
+}
+
+func Test_parseSnowflake(t *testing.T) {
+	// example from the Discord API reference
+	tm, worker, seq, err := ParseSnowflake("175928847299117063", DiscordEpoch)
+	if err != nil || tm.UnixMilli() != 1462015105796 || worker != 1<<5 || seq != 7 {
+		t.Fatalf("ParseSnowflake = %v, %d, %d, %v\n", tm, worker, seq, err)
+	}
+	// an ID minted in November 2022
+	tm, _, _, err = ParseSnowflake("1587455212633456640", TwitterEpoch)
+	if err != nil || tm.Year() != 2022 || tm.Month() != 11 {
+		t.Fatalf("ParseSnowflake = %v, %v\n", tm, err)
+	}
+	if _, _, _, err = ParseSnowflake("-1", TwitterEpoch); err == nil {
+		t.Fatalf("negative snowflake accepted")
+	}
+}
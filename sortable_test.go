@@ -0,0 +1,45 @@
+package rid
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_sortable(t *testing.T) {
+	var now = time.UnixMilli(1700000000123)
+	var id = newRIDSortableAt(now, 20)
+	if !ValidRIDSortable(id, 20) {
+		t.Fatalf("invalid sortable RID %s\n", id)
+	}
+	if tm, err := TimeOf(id); err != nil || !tm.Equal(now) {
+		t.Fatalf("TimeOf(%s) = %v, %v\n", id, tm, err)
+	}
+	// string order follows time order, whatever the random part
+	var later = newRIDSortableAt(now.Add(time.Millisecond), 20)
+	if later[:8] <= id[:8] || later <= id[:8]+"zzzzzzzzzzzz" {
+		t.Fatalf("sortable RIDs out of order: %s %s\n", id, later)
+	}
+	if _, err := TimeOf(id[:8]); err != ErrInvalidSortable {
+		t.Fatalf("timestamp without random part accepted")
+	}
+	if ValidRIDSortable(id[:19]+"-", 20) {
+		t.Fatalf("invalid character accepted")
+	}
+}
+
+func Test_sortableMonotonic(t *testing.T) {
+	var g = NewSortableGenerator(10, true)
+	var now = time.UnixMilli(1700000000123)
+	var prev string
+	for i := 0; i < 5000; i++ {
+		// the clock goes back halfway
+		var id = g.NewAt(now.Add(-time.Duration(i/2500) * time.Second))
+		if id <= prev {
+			t.Fatalf("sortable RIDs not increasing: %s <= %s\n", id, prev)
+		}
+		prev = id
+	}
+	if tm, _ := TimeOf(prev); tm.Before(now) {
+		t.Fatalf("timestamp went back: %v\n", tm)
+	}
+}
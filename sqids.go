@@ -0,0 +1,236 @@
+package rid
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	randv2 "math/rand/v2"
+	"strings"
+	"unicode"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Sqids, reversible encoding of non-negative integers to short non-sequential strings,
+// for database keys in URLs. Not encryption: anyone with the alphabet can decode.
+///////////////////////////////////////////////////////////////////////////
+
+const SqidsDefaultAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+var ErrInvalidSqid = errors.New("rid: invalid sqid")
+
+type Sqids struct {
+	alphabet  []byte
+	minLength int
+	blocklist []string
+}
+
+type SqidsOption func(*sqidsConfig)
+
+type sqidsConfig struct {
+	alphabet  string
+	minLength int
+	blocklist []string
+}
+
+// at least 3 distinct single-byte characters
+func WithSqidsAlphabet(alphabet string) SqidsOption {
+	return func(c *sqidsConfig) {
+		c.alphabet = alphabet
+	}
+}
+
+// pads IDs to at least n characters, up to 255
+func WithSqidsMinLength(n int) SqidsOption {
+	return func(c *sqidsConfig) {
+		c.minLength = n
+	}
+}
+
+// IDs containing these words are re-encoded. The Sqids libraries ship a default list,
+// here it is empty unless given, so IDs match other implementations configured with the same list.
+func WithSqidsBlocklist(words []string) SqidsOption {
+	return func(c *sqidsConfig) {
+		c.blocklist = words
+	}
+}
+
+func NewSqids(opts ...SqidsOption) (*Sqids, error) {
+	var c = sqidsConfig{alphabet: SqidsDefaultAlphabet}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	for i := 0; i < len(c.alphabet); i++ {
+		if c.alphabet[i] >= 0x80 {
+			return nil, errors.New("rid: sqids alphabet must be ASCII")
+		}
+	}
+	if len(c.alphabet) < 3 {
+		return nil, errors.New("rid: sqids alphabet needs at least 3 characters")
+	}
+	if err := checkAlphabet(c.alphabet); err != nil {
+		return nil, err
+	}
+	if c.minLength < 0 || c.minLength > 255 {
+		return nil, fmt.Errorf("rid: sqids min length %d out of range 0..255", c.minLength)
+	}
+	var s = &Sqids{alphabet: []byte(c.alphabet), minLength: c.minLength}
+	var lower = strings.ToLower(c.alphabet)
+	for _, w := range c.blocklist {
+		w = strings.ToLower(w)
+		if len(w) >= 3 && strings.Trim(w, lower) == "" {
+			s.blocklist = append(s.blocklist, w)
+		}
+	}
+	sqidsShuffle(s.alphabet)
+	return s, nil
+}
+
+// Encode fails only when every rotation of the alphabet produces a blocked ID
+func (s *Sqids) Encode(numbers ...uint64) (string, error) {
+	if len(numbers) == 0 {
+		return "", nil
+	}
+	return s.encode(numbers, 0)
+}
+
+func (s *Sqids) encode(numbers []uint64, increment int) (string, error) {
+	var n = uint64(len(s.alphabet))
+	if increment > len(s.alphabet) {
+		return "", errors.New("rid: sqids reached max attempts to avoid the blocklist")
+	}
+	var offset = uint64(len(numbers))
+	for i, v := range numbers {
+		offset += uint64(s.alphabet[v%n]) + uint64(i)
+	}
+	offset = (offset%n + uint64(increment)) % n
+	var alphabet = append(append(make([]byte, 0, n), s.alphabet[offset:]...), s.alphabet[:offset]...)
+	var id = []byte{alphabet[0]}
+	reverse(alphabet)
+	for i, v := range numbers {
+		id = sqidsAppendNumber(id, v, alphabet[1:])
+		if i < len(numbers)-1 {
+			id = append(id, alphabet[0])
+			sqidsShuffle(alphabet)
+		}
+	}
+	if len(id) < s.minLength {
+		id = append(id, alphabet[0])
+		for len(id) < s.minLength {
+			sqidsShuffle(alphabet)
+			id = append(id, alphabet[:min(s.minLength-len(id), len(alphabet))]...)
+		}
+	}
+	if s.blocked(string(id)) {
+		return s.encode(numbers, increment+1)
+	}
+	return string(id), nil
+}
+
+// Decode accepts only the canonical encoding, so that every set of numbers has exactly one valid ID
+func (s *Sqids) Decode(id string) ([]uint64, error) {
+	if id == "" {
+		return nil, ErrInvalidSqid
+	}
+	var offset = strings.IndexByte(string(s.alphabet), id[0])
+	if offset < 0 {
+		return nil, ErrInvalidSqid
+	}
+	var alphabet = append(append(make([]byte, 0, len(s.alphabet)), s.alphabet[offset:]...), s.alphabet[:offset]...)
+	reverse(alphabet)
+	var numbers []uint64
+	for rest := id[1:]; rest != ""; {
+		var chunk, tail, found = strings.Cut(rest, string(alphabet[0]))
+		if chunk == "" {
+			// padding of minLength follows
+			break
+		}
+		var v, ok = sqidsNumber(chunk, alphabet[1:])
+		if !ok {
+			return nil, ErrInvalidSqid
+		}
+		numbers = append(numbers, v)
+		if found {
+			sqidsShuffle(alphabet)
+		}
+		rest = tail
+	}
+	if canonical, err := s.Encode(numbers...); err != nil || canonical != id {
+		return nil, ErrInvalidSqid
+	}
+	return numbers, nil
+}
+
+func (s *Sqids) blocked(id string) bool {
+	id = strings.ToLower(id)
+	for _, w := range s.blocklist {
+		if len(w) > len(id) {
+			continue
+		}
+		if len(id) <= 3 || len(w) <= 3 {
+			if id == w {
+				return true
+			}
+		} else if strings.IndexFunc(w, unicode.IsDigit) >= 0 {
+			if strings.HasPrefix(id, w) || strings.HasSuffix(id, w) {
+				return true
+			}
+		} else if strings.Contains(id, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// the deterministic shuffle of the Sqids spec
+func sqidsShuffle(chars []byte) {
+	var n = len(chars)
+	for i, j := 0, n-1; j > 0; i, j = i+1, j-1 {
+		var r = (i*j + int(chars[i]) + int(chars[j])) % n
+		chars[i], chars[r] = chars[r], chars[i]
+	}
+}
+
+func sqidsAppendNumber(dst []byte, v uint64, alphabet []byte) []byte {
+	var n = uint64(len(alphabet))
+	var start = len(dst)
+	for {
+		dst = append(dst, alphabet[v%n])
+		v /= n
+		if v == 0 {
+			break
+		}
+	}
+	reverse(dst[start:])
+	return dst
+}
+
+func sqidsNumber(s string, alphabet []byte) (uint64, bool) {
+	var n = uint64(len(alphabet))
+	var v uint64
+	for i := 0; i < len(s); i++ {
+		var d = strings.IndexByte(string(alphabet), s[i])
+		if d < 0 || v > (^uint64(0)-uint64(d))/n {
+			return 0, false
+		}
+		v = v*n + uint64(d)
+	}
+	return v, true
+}
+
+func reverse(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
+
+// ShuffleAlphabet permutes alphabet deterministically by salt, for WithSqidsAlphabet.
+// It takes the place of the Hashids salt: IDs of different salts don't decode with each other's alphabet.
+func ShuffleAlphabet(alphabet, salt string) string {
+	var b = []byte(alphabet)
+	var r = randv2.NewChaCha8(sha256.Sum256([]byte(salt)))
+	for i := len(b) - 1; i > 0; i-- {
+		j, _ := uniformInt(r, i+1)
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
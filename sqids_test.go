@@ -0,0 +1,70 @@
+package rid
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_sqids(t *testing.T) {
+	s, err := NewSqids()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// from the Sqids spec tests
+	for id, want := range map[string][]uint64{
+		"86Rf07": {1, 2, 3},
+		"bM":     {0},
+		"Uk":     {1},
+		"nJ":     {9},
+	} {
+		if got, err := s.Encode(want...); err != nil || got != id {
+			t.Fatalf("Encode(%v) = %s, want %s\n", want, got, id)
+		}
+		if got, err := s.Decode(id); err != nil || !slices.Equal(got, want) {
+			t.Fatalf("Decode(%s) = %v, want %v\n", id, got, want)
+		}
+	}
+	s, _ = NewSqids(WithSqidsMinLength(len(SqidsDefaultAlphabet)))
+	if id, _ := s.Encode(1, 2, 3); id != "86Rf07xd4zBmiJXQG6otHEbew02c3PWsUOLZxADhCpKj7aVFv9I8RquYrNlSTM" {
+		t.Fatalf("padded sqid %s\n", id)
+	}
+}
+
+func Test_sqidsOptions(t *testing.T) {
+	var alphabet = ShuffleAlphabet(SqidsDefaultAlphabet, "salt")
+	if alphabet == SqidsDefaultAlphabet || alphabet != ShuffleAlphabet(SqidsDefaultAlphabet, "salt") {
+		t.Fatalf("ShuffleAlphabet not deterministic\n")
+	}
+	s, err := NewSqids(WithSqidsAlphabet(alphabet), WithSqidsMinLength(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []uint64{0, 1, 1000, 1<<64 - 1} {
+		id, err := s.Encode(v)
+		if err != nil || len(id) < 8 {
+			t.Fatalf("Encode(%d) = %s, %v\n", v, id, err)
+		}
+		if got, err := s.Decode(id); err != nil || len(got) != 1 || got[0] != v {
+			t.Fatalf("Decode(%s) = %v, %v\n", id, got, err)
+		}
+		if _, err = s.Decode(id + "a"); err == nil {
+			t.Fatalf("non-canonical sqid accepted")
+		}
+	}
+	// the blocklist forces another encoding
+	plain, _ := NewSqids()
+	blocked, _ := NewSqids(WithSqidsBlocklist([]string{"86Rf07"}))
+	id, _ := blocked.Encode(1, 2, 3)
+	if id == "86Rf07" {
+		t.Fatalf("blocked sqid produced")
+	}
+	if got, err := blocked.Decode(id); err != nil || !slices.Equal(got, []uint64{1, 2, 3}) {
+		t.Fatalf("Decode(%s) = %v, %v\n", id, got, err)
+	}
+	if _, err = plain.Decode("ab!"); err == nil {
+		t.Fatalf("invalid characters accepted")
+	}
+	if _, err = NewSqids(WithSqidsAlphabet("ab")); err == nil {
+		t.Fatalf("short alphabet accepted")
+	}
+}
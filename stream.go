@@ -0,0 +1,55 @@
+package rid
+
+import (
+	"context"
+	"iter"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Continuous and bulk generation
+///////////////////////////////////////////////////////////////////////////
+
+// StreamRIDn produces n-char RIDs until ctx is done, then closes the channel.
+// At most buffer IDs are generated ahead of the consumer.
+func StreamRIDn(ctx context.Context, n int, buffer int) <-chan string {
+	var ch = make(chan string, buffer)
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case ch <- NewRIDn(n):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// RIDs is an endless sequence of n-char RIDs, stop it with break:
+//
+//	for id := range rid.RIDs(20) { ... }
+func RIDs(n int) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for yield(NewRIDn(n)) {
+		}
+	}
+}
+
+// GenerateN returns count RIDs of given length, generated in batches.
+// It stops between batches when ctx is done and returns ctx.Err().
+func GenerateN(ctx context.Context, length, count int) ([]string, error) {
+	const batch = 4096
+	var result = make([]string, 0, count)
+	for len(result) < count {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var m = count - len(result)
+		if m > batch {
+			m = batch
+		}
+		result = append(result, NewRIDBatch(length, m)...)
+	}
+	return result, nil
+}
@@ -0,0 +1,43 @@
+package rid
+
+import (
+	"context"
+	"testing"
+)
+
+func Test_streamRIDn(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var ch = StreamRIDn(ctx, 16, 4)
+	for i := 0; i < 10; i++ {
+		if id := <-ch; !ValidRID16(id) {
+			t.Fatalf("invalid streamed id: %s\n", id)
+		}
+	}
+	cancel()
+	for range ch {
+	}
+}
+
+func Test_rids(t *testing.T) {
+	var count = 0
+	for id := range RIDs(20) {
+		if !ValidRID20(id) {
+			t.Fatalf("invalid id from sequence: %s\n", id)
+		}
+		if count++; count == 5 {
+			break
+		}
+	}
+}
+
+func Test_generateN(t *testing.T) {
+	ids, err := GenerateN(context.Background(), 16, 10000)
+	if err != nil || len(ids) != 10000 || !ValidRID16(ids[9999]) {
+		t.Fatalf("unexpected GenerateN result: %d ids, %v\n", len(ids), err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = GenerateN(ctx, 16, 10000); err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v\n", err)
+	}
+}
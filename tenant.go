@@ -0,0 +1,85 @@
+package rid
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"errors"
+	"sync"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Per-tenant keys derived from one master secret with HKDF-SHA256. A leaked tenant key doesn't reveal
+// the master or other tenants' keys, and tenants can be revoked one by one.
+///////////////////////////////////////////////////////////////////////////
+
+var ErrTenantRevoked = errors.New("rid: tenant revoked")
+
+type TenantKeys struct {
+	lk      sync.RWMutex
+	master  []byte
+	revoked map[string]bool
+}
+
+// NewTenantKeys takes a master secret of at least 32 random bytes
+func NewTenantKeys(master []byte) *TenantKeys {
+	if len(master) < 32 {
+		panic("rid: tenant master secret shorter than 32 bytes")
+	}
+	return &TenantKeys{master: master, revoked: make(map[string]bool)}
+}
+
+// SigningKey derives the 32-byte key of tenant for signed IDs, keys of different tenants are independent
+func (t *TenantKeys) SigningKey(tenant string) ([]byte, error) {
+	return t.key("rid tenant sign ", tenant)
+}
+
+// IndexKey derives the key of tenant for blind indexes. It differs from the signing key,
+// otherwise the index of a chosen value, e.g. a RID, would be its signature.
+func (t *TenantKeys) IndexKey(tenant string) ([]byte, error) {
+	return t.key("rid tenant index ", tenant)
+}
+
+func (t *TenantKeys) key(purpose, tenant string) ([]byte, error) {
+	t.lk.RLock()
+	defer t.lk.RUnlock()
+	if t.revoked[tenant] {
+		return nil, ErrTenantRevoked
+	}
+	return hkdf.Key(sha256.New, t.master, nil, purpose+tenant, 32)
+}
+
+// Revoke invalidates the signed IDs of tenant and stops issuing new ones
+func (t *TenantKeys) Revoke(tenant string) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	t.revoked[tenant] = true
+}
+
+func (t *TenantKeys) Restore(tenant string) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	delete(t.revoked, tenant)
+}
+
+// NewRID20Signed is NewRID20Signed with the key of tenant, the ID verifies only for the same tenant
+func (t *TenantKeys) NewRID20Signed(tenant string) (string, error) {
+	key, err := t.SigningKey(tenant)
+	if err != nil {
+		return "", err
+	}
+	return NewRID20Signed(string(key)), nil
+}
+
+func (t *TenantKeys) ValidRID20Signed(tenant, r string) bool {
+	key, err := t.SigningKey(tenant)
+	return err == nil && ValidRID20Signed(r, string(key))
+}
+
+// BlindIndex is BlindIndex with the key of tenant, so equal values of different tenants don't match
+func (t *TenantKeys) BlindIndex(tenant, value string, length int) (string, error) {
+	key, err := t.IndexKey(tenant)
+	if err != nil {
+		return "", err
+	}
+	return BlindIndex(value, string(key), length), nil
+}
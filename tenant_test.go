@@ -0,0 +1,45 @@
+package rid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_tenantKeys(t *testing.T) {
+	var tk = NewTenantKeys(bytes.Repeat([]byte("m"), 32))
+	k1, err1 := tk.SigningKey("acme")
+	k2, err2 := tk.SigningKey("globex")
+	if err1 != nil || err2 != nil || len(k1) != 32 || bytes.Equal(k1, k2) {
+		t.Fatalf("bad tenant keys %x, %x, %v, %v\n", k1, k2, err1, err2)
+	}
+	if again, _ := tk.SigningKey("acme"); !bytes.Equal(again, k1) {
+		t.Fatalf("tenant key not deterministic\n")
+	}
+	if ik, _ := tk.IndexKey("acme"); bytes.Equal(ik, k1) {
+		t.Fatalf("tenant index key equals signing key\n")
+	}
+	// the full-length index of a RID must not be its signature
+	id, err := tk.NewRID20Signed("acme")
+	if full, _ := tk.BlindIndex("acme", id[:20], 43); HMACBase62(id[:20], string(k1), MAC256) == full {
+		t.Fatalf("blind index reveals the signature of %s\n", id)
+	}
+	if err != nil || !tk.ValidRID20Signed("acme", id) || tk.ValidRID20Signed("globex", id) {
+		t.Fatalf("tenant-signed RID %s wrong, %v\n", id, err)
+	}
+	b1, _ := tk.BlindIndex("acme", "alice@example.com", 16)
+	b2, _ := tk.BlindIndex("globex", "alice@example.com", 16)
+	if len(b1) != 16 || b1 == b2 {
+		t.Fatalf("tenant blind indexes %s, %s\n", b1, b2)
+	}
+	tk.Revoke("acme")
+	if tk.ValidRID20Signed("acme", id) || !tk.ValidRID20Signed("globex", NewRID20Signed(string(k2))) {
+		t.Fatalf("revocation affects the wrong tenant\n")
+	}
+	if _, err = tk.NewRID20Signed("acme"); err != ErrTenantRevoked {
+		t.Fatalf("revoked tenant signs, %v\n", err)
+	}
+	tk.Restore("acme")
+	if !tk.ValidRID20Signed("acme", id) {
+		t.Fatalf("restored tenant RID %s invalid\n", id)
+	}
+}
@@ -0,0 +1,140 @@
+package rid
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"math"
+	"math/big"
+	"strings"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Tokens rendered from raw crypto bytes
+///////////////////////////////////////////////////////////////////////////
+
+// NewToken returns base64url encoded (no padding) random bytes from crypto/rand.
+// The alphabet has 64 characters, so there is no sampling bias and every character carries 6 bits.
+func NewToken(bytes int) string {
+	t, err := NewTokenE(bytes)
+	if err != nil {
+		//severe error - looks like a failure of system random number generator
+		log.Fatal(err)
+	}
+	return t
+}
+
+func NewTokenE(bytes int) (string, error) {
+	var b = make([]byte, bytes)
+	if _, err := io.ReadFull(cryptoReader(), b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// ValidBase64Token checks that token is the canonical encoding of the given number of bytes
+func ValidBase64Token(token string, bytes int) bool {
+	if len(token) != base64.RawURLEncoding.EncodedLen(bytes) {
+		return false
+	}
+	_, err := base64.RawURLEncoding.Strict().DecodeString(token)
+	return err == nil
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Secret tokens with a recognizable prefix and a checksum, as GitHub's ghp_ tokens:
+// prefix, underscore, 30 random base62 chars and 6 base62 chars of CRC32 of the random part
+///////////////////////////////////////////////////////////////////////////
+
+// 178.6 bits of entropy
+const tokenBodyLength = 30
+
+var tokenBodies = NewCrypto(WithLength(tokenBodyLength))
+
+// NewChecksumToken reads from crypto/rand. The prefix identifies the token for secret scanners
+// and must be valid for RegisterPrefix, e.g. "acme_pat" gives acme_pat_<36 chars>.
+func NewChecksumToken(prefix string) string {
+	if !ValidPrefix(prefix) {
+		panic("rid: invalid token prefix " + prefix)
+	}
+	var body = tokenBodies.NewID()
+	return prefix + "_" + body + tokenChecksum(body)
+}
+
+// ValidToken checks the structure and the checksum of a NewChecksumToken, catching typos without a database lookup.
+// It says nothing about whether the token was issued.
+func ValidToken(token string) bool {
+	var i = strings.LastIndexByte(token, '_')
+	if i < 0 || !ValidPrefix(token[:i]) {
+		return false
+	}
+	var rest = token[i+1:]
+	if len(rest) != tokenBodyLength+6 || !b62regexp.MatchString(rest) {
+		return false
+	}
+	return rest[tokenBodyLength:] == tokenChecksum(rest[:tokenBodyLength])
+}
+
+// 6 chars of zero-padded base62 of the CRC32, in digits first order
+func tokenChecksum(body string) string {
+	s, _ := intToDigits(big.NewInt(int64(crc32.ChecksumIEEE([]byte(body)))), Base62SortableAlphabet, 6)
+	return s
+}
+
+///////////////////////////////////////////////////////////////////////////
+// YouTube-style IDs, 11 chars of base64url encoding a 64-bit value
+///////////////////////////////////////////////////////////////////////////
+
+// 64 random bits from the same source as NewRIDn
+func NewYouTubeID() string {
+	return base64.RawURLEncoding.EncodeToString(randomBytes(8))
+}
+
+// 11 chars of base64url, the last one from the 16 whose 2 low bits are zero
+func ValidYouTubeID(s string) bool {
+	return ValidBase64Token(s, 8)
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Session tokens sized by a security level in bits
+///////////////////////////////////////////////////////////////////////////
+
+const base64URLAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+// SessionTokenLength returns the chars of an alphabet of the given size needed for at least bits of entropy
+func SessionTokenLength(bits, alphabetSize int) int {
+	return int(math.Ceil(float64(bits) / math.Log2(float64(alphabetSize))))
+}
+
+// NewSessionToken returns base62 chars from crypto/rand carrying at least bits of entropy,
+// 22 chars for 128 bits and 43 chars for 256 bits. Less than 64 bits panics.
+func NewSessionToken(bits int) string {
+	return newSessionToken(bits, B62ascii)
+}
+
+// As NewSessionToken in base64url, 22 chars for 128 bits and 43 chars for 256 bits
+func NewSessionTokenBase64(bits int) string {
+	return newSessionToken(bits, []byte(base64URLAlphabet))
+}
+
+func newSessionToken(bits int, alphabet []byte) string {
+	if bits < 64 {
+		panic(fmt.Sprintf("rid: %d bits are too few for a session token, use 128", bits))
+	}
+	var n = SessionTokenLength(bits, len(alphabet))
+	b, err := appendAlphabet(make([]byte, 0, n), cryptoReader(), alphabet, n)
+	if err != nil {
+		//severe error - looks like a failure of system random number generator
+		log.Fatal(err)
+	}
+	return string(b)
+}
+
+// VerifySessionToken compares the presented token with the stored one in constant time.
+// Prefer storing a hash of the token and comparing hashes, so a database leak doesn't leak sessions.
+func VerifySessionToken(presented, stored string) bool {
+	return subtle.ConstantTimeCompare([]byte(presented), []byte(stored)) == 1
+}
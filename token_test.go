@@ -0,0 +1,60 @@
+package rid
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_token(t *testing.T) {
+	var token = NewToken(32)
+	if len(token) != 43 || !ValidBase64Token(token, 32) {
+		t.Fatalf("invalid token: %s\n", token)
+	}
+	if ValidBase64Token(token, 31) || ValidBase64Token(token[:42]+"+", 32) {
+		t.Fatalf("token validator too lenient")
+	}
+	// last character carries 2 unused bits, which must be zero in the canonical form
+	if ValidBase64Token("AB", 1) {
+		t.Fatalf("non-canonical token should be rejected")
+	}
+}
+
+func Test_checksumToken(t *testing.T) {
+	var token = NewChecksumToken("acme_pat")
+	if len(token) != 45 || !ValidToken(token) {
+		t.Fatalf("invalid token: %s\n", token)
+	}
+	// a single typo changes the CRC32
+	var b = []byte(token)
+	b[12] ^= 0x01
+	if ValidToken(string(b)) {
+		t.Fatalf("typo not detected: %s\n", b)
+	}
+	if ValidToken(token[:44]) || ValidToken(token[9:]) {
+		t.Fatalf("truncated token accepted")
+	}
+}
+
+func Test_youTubeID(t *testing.T) {
+	var id = NewYouTubeID()
+	if len(id) != 11 || !ValidYouTubeID(id) {
+		t.Fatalf("invalid YouTube-style ID %s\n", id)
+	}
+	// existing IDs in the same shape
+	if !ValidYouTubeID("dQw4w9WgXcQ") || ValidYouTubeID("dQw4w9WgXcR") || ValidYouTubeID("dQw4w9WgXc") {
+		t.Fatalf("YouTube-style validator wrong")
+	}
+}
+
+func Test_sessionToken(t *testing.T) {
+	var token = NewSessionToken(128)
+	if len(token) != 22 || !b62regexp.MatchString(token) || !VerifySessionToken(token, token) {
+		t.Fatalf("invalid session token %s\n", token)
+	}
+	if token = NewSessionTokenBase64(256); len(token) != 43 || strings.Trim(token, base64URLAlphabet) != "" {
+		t.Fatalf("invalid session token %s\n", token)
+	}
+	if VerifySessionToken(token, token[:42]) || VerifySessionToken(token, NewSessionTokenBase64(256)) {
+		t.Fatalf("different session tokens verified")
+	}
+}
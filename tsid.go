@@ -0,0 +1,120 @@
+package rid
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// TSID, 64-bit time-sorted IDs of 42 bits of milliseconds since 2020 and 22 bits of node and counter,
+// in 13 chars of Crockford base32. Compatible with tsid-creator for Java.
+///////////////////////////////////////////////////////////////////////////
+
+type TSID int64
+
+var ErrInvalidTSID = errors.New("rid: invalid TSID")
+
+var ErrTSIDConfig = errors.New("rid: invalid TSID node config")
+
+// 2020-01-01T00:00:00Z
+var TSIDEpoch = time.UnixMilli(1577836800000)
+
+const tsidRandomBits = 22
+
+func NewTSID() string {
+	return defaultTSIDs.New().String()
+}
+
+// like tsid-creator without configuration, 10 node bits and a random node
+var defaultTSIDs = func() *TSIDGenerator {
+	g, _ := NewTSIDGenerator(10, int64(binary.BigEndian.Uint16(randomBytes(2))&(1<<10-1)))
+	return g
+}()
+
+// TSIDGenerator gives every millisecond a random counter and increments it for the next TSIDs of the same millisecond.
+// When the counter overflows, or the clock goes back, the timestamp continues from the last one,
+// so the TSIDs of one generator are strictly increasing.
+type TSIDGenerator struct {
+	lk       sync.Mutex
+	nodeBits uint
+	node     int64
+	lastMs   int64
+	counter  int64
+}
+
+// nodeBits must be at most 20, leaving at least 2 counter bits, and node must fit in nodeBits
+func NewTSIDGenerator(nodeBits uint, node int64) (*TSIDGenerator, error) {
+	if nodeBits > 20 || node < 0 || node >= 1<<nodeBits {
+		return nil, ErrTSIDConfig
+	}
+	return &TSIDGenerator{nodeBits: nodeBits, node: node, lastMs: -1}, nil
+}
+
+func (g *TSIDGenerator) New() TSID {
+	return g.NewAt(time.Now())
+}
+
+func (g *TSIDGenerator) NewAt(t time.Time) TSID {
+	var counterBits = tsidRandomBits - g.nodeBits
+	var ms = t.UnixMilli() - TSIDEpoch.UnixMilli()
+	g.lk.Lock()
+	defer g.lk.Unlock()
+	if ms <= g.lastMs {
+		g.counter++
+		ms = g.lastMs + g.counter>>counterBits
+		g.counter &= 1<<counterBits - 1
+	} else {
+		g.counter = int64(binary.BigEndian.Uint32(randomBytes(4))) & (1<<counterBits - 1)
+	}
+	g.lastMs = ms
+	return TSID(ms<<tsidRandomBits | g.node<<counterBits | g.counter)
+}
+
+// Node returns the node ID of a TSID generated with the same number of node bits as g
+func (g *TSIDGenerator) Node(id TSID) int64 {
+	return int64(id) >> (tsidRandomBits - g.nodeBits) & (1<<g.nodeBits - 1)
+}
+
+// Time returns the embedded timestamp with millisecond precision
+func (id TSID) Time() time.Time {
+	return time.UnixMilli(int64(id)>>tsidRandomBits + TSIDEpoch.UnixMilli())
+}
+
+// 13 chars of uppercase Crockford base32, the first one encodes the top 4 bits
+func (id TSID) String() string {
+	var b [13]byte
+	var x = uint64(id)
+	for i := 12; i >= 0; i-- {
+		b[i] = CrockfordAlphabet[x&31]
+		x >>= 5
+	}
+	return string(b[:])
+}
+
+// ParseTSID is case-insensitive and resolves the Crockford aliases I, L -> 1 and O -> 0
+func ParseTSID(s string) (TSID, error) {
+	if len(s) != 13 {
+		return 0, ErrInvalidTSID
+	}
+	var n, err = NormalizeCrockford(s)
+	if err != nil || len(n) != 13 || !crockford.valid(n) {
+		return 0, ErrInvalidTSID
+	}
+	var x uint64
+	for i := 0; i < len(n); i++ {
+		x = x<<5 | uint64(strings.IndexByte(CrockfordAlphabet, n[i]))
+	}
+	// the first char carries only 4 bits
+	if n[0] > 'F' {
+		return 0, ErrInvalidTSID
+	}
+	return TSID(x), nil
+}
+
+func ValidTSID(s string) bool {
+	_, err := ParseTSID(s)
+	return err == nil
+}
@@ -0,0 +1,51 @@
+package rid
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_tsid(t *testing.T) {
+	g, err := NewTSIDGenerator(8, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	var prev TSID
+	for i := 0; i < 20000; i++ {
+		var id = g.NewAt(now)
+		if id <= prev {
+			t.Fatalf("TSIDs not increasing: %d <= %d\n", id, prev)
+		}
+		if g.Node(id) != 200 {
+			t.Fatalf("wrong node %d\n", g.Node(id))
+		}
+		prev = id
+	}
+	// 20000 IDs overflow the 14-bit counter, the timestamp moves at most 2 ms ahead
+	if d := prev.Time().Sub(now); d < 0 || d > 2*time.Millisecond {
+		t.Fatalf("unexpected timestamp %v\n", prev.Time())
+	}
+	var s = prev.String()
+	if len(s) != 13 || !ValidTSID(s) {
+		t.Fatalf("invalid TSID string %s\n", s)
+	}
+	back, err := ParseTSID(s)
+	if err != nil || back != prev {
+		t.Fatalf("round trip failed: %s %v\n", s, err)
+	}
+}
+
+func Test_tsidString(t *testing.T) {
+	// 13 Crockford digits of the 64-bit value, case-insensitive
+	id, err := ParseTSID("0awe5hzp3sktk")
+	if err != nil || id != 392311864492347219 || id.String() != "0AWE5HZP3SKTK" {
+		t.Fatalf("unexpected TSID %d, %v\n", id, err)
+	}
+	if ValidTSID("GAWE5HZP3SKTK") || ValidTSID("0AWE5HZP3SKT") {
+		t.Fatalf("invalid TSIDs accepted")
+	}
+	if _, err = NewTSIDGenerator(21, 0); err != ErrTSIDConfig {
+		t.Fatalf("too many node bits should be rejected")
+	}
+}
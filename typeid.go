@@ -0,0 +1,115 @@
+package rid
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// TypeID, a type prefix and a UUIDv7 in 26 chars of lowercase Crockford base32, e.g. user_01h455vb4pex5vsknk084sn02q
+///////////////////////////////////////////////////////////////////////////
+
+type TypeID struct {
+	prefix string
+	uuid   UUID
+}
+
+var ErrInvalidTypeID = errors.New("rid: invalid TypeID")
+
+var ErrTypeIDPrefix = errors.New("rid: TypeID prefix mismatch")
+
+const typeIDAlphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+
+// prefix may be empty, otherwise see ValidTypeIDPrefix
+func NewTypeID(prefix string) (string, error) {
+	if prefix != "" && !ValidTypeIDPrefix(prefix) {
+		return "", ErrInvalidTypeID
+	}
+	return TypeID{prefix, newUUID7At(time.Now())}.String(), nil
+}
+
+// MustTypeID is like NewTypeID but panics on invalid prefix
+func MustTypeID(prefix string) string {
+	id, err := NewTypeID(prefix)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// at most 63 chars of lowercase a-z and underscores, starting and ending with a letter
+func ValidTypeIDPrefix(prefix string) bool {
+	var n = len(prefix)
+	if n == 0 || n > 63 || prefix[0] == '_' || prefix[n-1] == '_' {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		if (prefix[i] < 'a' || prefix[i] > 'z') && prefix[i] != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+func (t TypeID) Prefix() string {
+	return t.prefix
+}
+
+func (t TypeID) UUID() UUID {
+	return t.uuid
+}
+
+// Time returns the timestamp of the UUIDv7 suffix
+func (t TypeID) Time() time.Time {
+	ts, _ := TimeFromUUID7(t.uuid.String())
+	return ts
+}
+
+func (t TypeID) String() string {
+	s, _ := intToDigits(new(big.Int).SetBytes(t.uuid[:]), typeIDAlphabet, 26)
+	if t.prefix == "" {
+		return s
+	}
+	return t.prefix + "_" + s
+}
+
+// ParseTypeID splits s at the last underscore. Only lowercase is accepted.
+func ParseTypeID(s string) (TypeID, error) {
+	var t TypeID
+	var suffix = s
+	if i := strings.LastIndexByte(s, '_'); i >= 0 {
+		t.prefix, suffix = s[:i], s[i+1:]
+		if !ValidTypeIDPrefix(t.prefix) {
+			return TypeID{}, ErrInvalidTypeID
+		}
+	}
+	// 26 chars carry 130 bits, the first char must not exceed 7
+	if len(suffix) != 26 || suffix[0] > '7' {
+		return TypeID{}, ErrInvalidTypeID
+	}
+	x, ok := digitsToInt(suffix, typeIDAlphabet)
+	if !ok {
+		return TypeID{}, ErrInvalidTypeID
+	}
+	x.FillBytes(t.uuid[:])
+	return t, nil
+}
+
+// ParseTypeIDWithPrefix is like ParseTypeID and also requires the given prefix
+func ParseTypeIDWithPrefix(prefix, s string) (TypeID, error) {
+	t, err := ParseTypeID(s)
+	if err != nil {
+		return t, err
+	}
+	if t.prefix != prefix {
+		return TypeID{}, ErrTypeIDPrefix
+	}
+	return t, nil
+}
+
+func ValidTypeID(prefix, s string) bool {
+	_, err := ParseTypeIDWithPrefix(prefix, s)
+	return err == nil
+}
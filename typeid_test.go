@@ -0,0 +1,48 @@
+package rid
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_typeID(t *testing.T) {
+	var start = time.Now().Truncate(time.Millisecond)
+	id, err := NewTypeID("user")
+	if err != nil || len(id) != 31 || !ValidTypeID("user", id) {
+		t.Fatalf("invalid TypeID %s, %v\n", id, err)
+	}
+	tid, _ := ParseTypeID(id)
+	if tid.Prefix() != "user" || tid.UUID().Version() != 7 || tid.Time().Before(start) {
+		t.Fatalf("unexpected TypeID components %v\n", tid)
+	}
+	if _, err = ParseTypeIDWithPrefix("order", id); err != ErrTypeIDPrefix {
+		t.Fatalf("prefix mismatch not detected")
+	}
+	if _, err = NewTypeID("User"); err == nil {
+		t.Fatalf("uppercase prefix accepted")
+	}
+}
+
+func Test_typeIDSpec(t *testing.T) {
+	// from the TypeID spec valid cases
+	var u, _ = ParseUUID("01890a5d-ac96-774b-bcce-b302099a8057")
+	tid, err := ParseTypeID("prefix_01h455vb4pex5vsknk084sn02q")
+	if err != nil || tid.UUID() != u || tid.Prefix() != "prefix" || tid.String() != "prefix_01h455vb4pex5vsknk084sn02q" {
+		t.Fatalf("spec vector failed: %v, %v\n", tid, err)
+	}
+	if tid, err = ParseTypeID("00000000000000000000000000"); err != nil || tid.UUID() != (UUID{}) || tid.Prefix() != "" {
+		t.Fatalf("nil TypeID failed: %v\n", err)
+	}
+	for _, s := range []string{
+		"_01h455vb4pex5vsknk084sn02q",
+		"prefix_81h455vb4pex5vsknk084sn02q",
+		"prefix_01H455VB4PEX5VSKNK084SN02Q",
+		"prefix__01h455vb4pex5vsknk084sn02q",
+		"pre-fix_01h455vb4pex5vsknk084sn02q",
+		"prefix_01h455vb4pex5vsknk084sn02",
+	} {
+		if _, err = ParseTypeID(s); err == nil {
+			t.Fatalf("invalid TypeID %s accepted\n", s)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package rid
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// ULID, 48-bit millisecond timestamp and 80 random bits in 26 chars of Crockford base32,
+// lexicographically sortable by time
+///////////////////////////////////////////////////////////////////////////
+
+type ULID [16]byte
+
+var ErrInvalidULID = errors.New("rid: invalid ULID")
+
+// maximum timestamp of a ULID, year 10889
+const maxULIDTime = 1<<48 - 1
+
+func NewULID() string {
+	return defaultULIDs.New().String()
+}
+
+var defaultULIDs = NewULIDGenerator(false)
+
+// ULIDGenerator takes randomness from the same source as NewRIDn.
+// With monotonic set, ULIDs within the same millisecond increment the random part of the previous one,
+// so the ULIDs of one generator are strictly increasing even when the clock stands still or goes back.
+type ULIDGenerator struct {
+	lk        sync.Mutex
+	monotonic bool
+	last      ULID
+}
+
+func NewULIDGenerator(monotonic bool) *ULIDGenerator {
+	return &ULIDGenerator{monotonic: monotonic}
+}
+
+func (g *ULIDGenerator) New() ULID {
+	return g.NewAt(time.Now())
+}
+
+func (g *ULIDGenerator) NewAt(t time.Time) ULID {
+	var u ULID
+	var ms = uint64(t.UnixMilli())
+	if !g.monotonic {
+		setULIDTime(&u, ms)
+		copy(u[6:], randomBytes(10))
+		return u
+	}
+	g.lk.Lock()
+	defer g.lk.Unlock()
+	if last := g.last.ms(); ms <= last && g.last != (ULID{}) {
+		u = g.last
+		// increment the 80-bit random part, on overflow carry into the timestamp
+		for i := 15; i >= 0; i-- {
+			u[i]++
+			if u[i] != 0 {
+				break
+			}
+		}
+	} else {
+		setULIDTime(&u, ms)
+		copy(u[6:], randomBytes(10))
+	}
+	g.last = u
+	return u
+}
+
+func setULIDTime(u *ULID, ms uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], ms&maxULIDTime)
+	copy(u[:6], b[2:])
+}
+
+func (u ULID) ms() uint64 {
+	var b [8]byte
+	copy(b[2:], u[:6])
+	return binary.BigEndian.Uint64(b[:])
+}
+
+// Time returns the embedded timestamp with millisecond precision
+func (u ULID) Time() time.Time {
+	return time.UnixMilli(int64(u.ms()))
+}
+
+// 26 chars of uppercase Crockford base32
+func (u ULID) String() string {
+	s, _ := intToDigits(new(big.Int).SetBytes(u[:]), CrockfordAlphabet, 26)
+	return s
+}
+
+// ParseULID is case-insensitive and resolves the Crockford aliases I, L -> 1 and O -> 0
+func ParseULID(s string) (ULID, error) {
+	var u ULID
+	if len(s) != 26 {
+		return u, ErrInvalidULID
+	}
+	var n, err = NormalizeCrockford(s)
+	if err != nil || len(n) != 26 || !crockford.valid(n) {
+		return u, ErrInvalidULID
+	}
+	x, _ := digitsToInt(n, CrockfordAlphabet)
+	if x.BitLen() > 128 {
+		return u, ErrInvalidULID
+	}
+	x.FillBytes(u[:])
+	return u, nil
+}
+
+func ValidULID(s string) bool {
+	_, err := ParseULID(s)
+	return err == nil
+}
@@ -0,0 +1,42 @@
+package rid
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_ulid(t *testing.T) {
+	var s = NewULID()
+	u, err := ParseULID(strings.ToLower(s))
+	if len(s) != 26 || err != nil || u.String() != s || s[0] > '7' {
+		t.Fatalf("ULID round trip failed: %s, %v\n", s, err)
+	}
+	if d := time.Since(u.Time()); d < 0 || d > time.Minute {
+		t.Fatalf("unexpected ULID time: %v\n", u.Time())
+	}
+	// example from the ULID spec: 1469918176385 ms = 01ARYZ6S41
+	var at = NewULIDGenerator(false).NewAt(time.UnixMilli(1469918176385))
+	if !strings.HasPrefix(at.String(), "01ARYZ6S41") {
+		t.Fatalf("unexpected timestamp encoding: %s\n", at)
+	}
+	for _, bad := range []string{"", "8ZZZZZZZZZZZZZZZZZZZZZZZZZ", "01ARYZ6S41-TSV4RRFFQ69G5FA", "01ARYZ6S41TSV4RRFFQ69G5FAU"} {
+		if ValidULID(bad) {
+			t.Fatalf("%s should be invalid\n", bad)
+		}
+	}
+}
+
+func Test_ulidMonotonic(t *testing.T) {
+	var g = NewULIDGenerator(true)
+	var now = time.Now()
+	var prev = g.NewAt(now).String()
+	for i := 0; i < 1000; i++ {
+		// the clock standing still or going back must not break ordering
+		var next = g.NewAt(now.Add(-time.Duration(i%3) * time.Millisecond)).String()
+		if next <= prev {
+			t.Fatalf("ULIDs not increasing: %s <= %s\n", next, prev)
+		}
+		prev = next
+	}
+}
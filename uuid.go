@@ -0,0 +1,226 @@
+package rid
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// RFC 4122 UUIDs and their short renderings
+///////////////////////////////////////////////////////////////////////////
+
+type UUID [16]byte
+
+var ErrInvalidUUID = errors.New("rid: invalid UUID")
+
+// ParseUUID accepts the canonical hyphenated form, in any case
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, ErrInvalidUUID
+	}
+	var h = s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	if _, err := hex.Decode(u[:], []byte(h)); err != nil {
+		return u, ErrInvalidUUID
+	}
+	return u, nil
+}
+
+// canonical lowercase hyphenated form
+func (u UUID) String() string {
+	var b = make([]byte, 36)
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b)
+}
+
+///////////////////////////////////////////////////////////////////////////
+// 22-char short forms
+///////////////////////////////////////////////////////////////////////////
+
+// alphabet of the shortuuid libraries (Python shortuuid, github.com/lithammer/shortuuid):
+// base57, without the look-alikes 0, 1, I, O and l
+const ShortUUIDAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Base62 renders u as 22 characters of the RID alphabet, left padded with 'A'
+// it is the RID22 of the UUID bytes
+func (u UUID) Base62() string {
+	return RID22FromBytes(u)
+}
+
+func ParseUUIDBase62(s string) (UUID, error) {
+	b, err := RID22Bytes(s)
+	if err != nil {
+		return UUID{}, ErrInvalidUUID
+	}
+	return b, nil
+}
+
+// Short renders u like shortuuid.encode: base57 most significant digit first, left padded to 22 characters
+func (u UUID) Short() string {
+	s, _ := intToDigits(new(big.Int).SetBytes(u[:]), ShortUUIDAlphabet, 22)
+	return s
+}
+
+func ParseShortUUID(s string) (UUID, error) {
+	if len(s) != 22 {
+		return UUID{}, ErrInvalidUUID
+	}
+	x, ok := digitsToInt(s, ShortUUIDAlphabet)
+	if !ok || x.BitLen() > 128 {
+		return UUID{}, ErrInvalidUUID
+	}
+	var u UUID
+	x.FillBytes(u[:])
+	return u, nil
+}
+
+///////////////////////////////////////////////////////////////////////////
+// UUID versions
+///////////////////////////////////////////////////////////////////////////
+
+// Version returns the version field, the high nibble of byte 6
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// true for the RFC 4122 variant, bits 10 in the top of byte 8
+func (u UUID) IsRFC4122() bool {
+	return u[8]&0xc0 == 0x80
+}
+
+func setVersion(u *UUID, version byte) {
+	u[6] = u[6]&0x0f | version<<4
+	u[8] = u[8]&0x3f | 0x80
+}
+
+// NewUUID4 returns a random UUID in canonical form, 122 random bits from the same source as NewRIDn
+func NewUUID4() string {
+	var u UUID
+	copy(u[:], randomBytes(16))
+	setVersion(&u, 4)
+	return u.String()
+}
+
+// ValidUUID4 accepts a canonical UUID of version 4 and RFC 4122 variant
+func ValidUUID4(s string) bool {
+	u, err := ParseUUID(s)
+	return err == nil && u.Version() == 4 && u.IsRFC4122()
+}
+
+// NewUUID7 returns a time-ordered UUID: 48-bit Unix millisecond timestamp followed by 74 random bits
+func NewUUID7() string {
+	return newUUID7At(time.Now()).String()
+}
+
+func newUUID7At(t time.Time) UUID {
+	var u UUID
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(t.UnixMilli()))
+	copy(u[:6], b[2:])
+	copy(u[6:], randomBytes(10))
+	setVersion(&u, 7)
+	return u
+}
+
+// UUID7Generator takes randomness from the same source as NewRIDn.
+// With monotonic set, UUIDs within the same millisecond increment the 74 random bits of the previous one,
+// as in RFC 9562 section 6.2 method 2, so the UUIDs of one generator are strictly increasing.
+type UUID7Generator struct {
+	lk        sync.Mutex
+	monotonic bool
+	last      UUID
+}
+
+func NewUUID7Generator(monotonic bool) *UUID7Generator {
+	return &UUID7Generator{monotonic: monotonic}
+}
+
+func (g *UUID7Generator) New() UUID {
+	return g.NewAt(time.Now())
+}
+
+func (g *UUID7Generator) NewAt(t time.Time) UUID {
+	if !g.monotonic {
+		return newUUID7At(t)
+	}
+	g.lk.Lock()
+	defer g.lk.Unlock()
+	var last = g.last
+	if last != (UUID{}) && uuid7Ms(last) >= uint64(t.UnixMilli()) {
+		// rand_b is the low 62 bits, rand_a the 12 bits after the version, on overflow carry into the timestamp
+		var randB = binary.BigEndian.Uint64(last[8:]) & (1<<62 - 1)
+		var randA = binary.BigEndian.Uint16(last[6:]) & 0x0fff
+		var ms = uuid7Ms(last)
+		if randB++; randB == 1<<62 {
+			randB = 0
+			if randA++; randA == 1<<12 {
+				randA = 0
+				ms++
+			}
+		}
+		var u UUID
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], ms)
+		copy(u[:6], b[2:])
+		binary.BigEndian.PutUint16(u[6:], randA)
+		binary.BigEndian.PutUint64(u[8:], randB)
+		setVersion(&u, 7)
+		g.last = u
+		return u
+	}
+	g.last = newUUID7At(t)
+	return g.last
+}
+
+func uuid7Ms(u UUID) uint64 {
+	var b [8]byte
+	copy(b[2:], u[:6])
+	return binary.BigEndian.Uint64(b[:])
+}
+
+// TimeFromUUID7 extracts the millisecond timestamp of a canonical UUIDv7
+func TimeFromUUID7(id string) (time.Time, error) {
+	u, err := ParseUUID(id)
+	if err != nil || u.Version() != 7 || !u.IsRFC4122() {
+		return time.Time{}, ErrInvalidUUID
+	}
+	return time.UnixMilli(int64(uuid7Ms(u))), nil
+}
+
+func ValidUUID7(s string) bool {
+	_, err := TimeFromUUID7(s)
+	return err == nil
+}
+
+// RFC 4122 namespaces for NewUUID5
+var (
+	NamespaceDNS  = UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	NamespaceURL  = UUID{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	NamespaceOID  = UUID{0x6b, 0xa7, 0xb8, 0x12, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	NamespaceX500 = UUID{0x6b, 0xa7, 0xb8, 0x14, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+)
+
+// NewUUID5 derives a UUID from namespace and name with SHA-1, the same input always gives the same UUID.
+// Use the Base62 method for the 22-char form.
+func NewUUID5(namespace UUID, name string) UUID {
+	var h = sha1.New()
+	h.Write(namespace[:])
+	h.Write([]byte(name))
+	var u UUID
+	copy(u[:], h.Sum(nil))
+	setVersion(&u, 5)
+	return u
+}
@@ -0,0 +1,104 @@
+package rid
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseUUID(t *testing.T) {
+	var s = "3b1f8b40-222c-4a6e-b77e-779d5a94e21c"
+	u, err := ParseUUID("3B1F8B40-222C-4A6E-B77E-779D5A94E21C")
+	if err != nil || u.String() != s {
+		t.Fatalf("unexpected UUID: %s, %v\n", u, err)
+	}
+	for _, bad := range []string{"", "3b1f8b40222c4a6eb77e779d5a94e21c", "3b1f8b40-222c-4a6e-b77e-779d5a94e21g"} {
+		if _, err = ParseUUID(bad); err != ErrInvalidUUID {
+			t.Fatalf("%s should be rejected\n", bad)
+		}
+	}
+}
+
+func Test_shortUUID(t *testing.T) {
+	u, _ := ParseUUID("3b1f8b40-222c-4a6e-b77e-779d5a94e21c")
+	// example from the Python shortuuid README
+	if s := u.Short(); s != "CXc85b4rqinB7s5J52TRYb" {
+		t.Fatalf("unexpected shortuuid: %s\n", s)
+	}
+	if s := u.Base62(); s != "Bxi7rb6350ZUE3o3L3pok8" {
+		t.Fatalf("unexpected base62 UUID: %s\n", s)
+	}
+	for _, max := range []UUID{{}, {255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255}, u} {
+		a, err1 := ParseShortUUID(max.Short())
+		b, err2 := ParseUUIDBase62(max.Base62())
+		if a != max || b != max || err1 != nil || err2 != nil {
+			t.Fatalf("round trip of %s failed: %s, %s\n", max, a, b)
+		}
+	}
+	if _, err := ParseUUIDBase62("9999999999999999999999"); err != ErrInvalidUUID {
+		t.Fatalf("value above 128 bits should be rejected")
+	}
+}
+
+func Test_uuid4(t *testing.T) {
+	var s = NewUUID4()
+	if !ValidUUID4(s) || s[14] != '4' || !strings.ContainsRune("89ab", rune(s[19])) {
+		t.Fatalf("invalid UUIDv4: %s\n", s)
+	}
+	if NewUUID4() == s {
+		t.Fatalf("UUIDs should differ")
+	}
+	if ValidUUID4("3b1f8b40-222c-1a6e-b77e-779d5a94e21c") || ValidUUID4("3b1f8b40-222c-4a6e-c77e-779d5a94e21c") {
+		t.Fatalf("wrong version or variant accepted")
+	}
+}
+
+func Test_uuid7(t *testing.T) {
+	var s = NewUUID7()
+	tm, err := TimeFromUUID7(s)
+	if err != nil || !ValidUUID7(s) || s[14] != '7' || time.Since(tm) > time.Minute {
+		t.Fatalf("invalid UUIDv7: %s, %v, %v\n", s, tm, err)
+	}
+	// example from RFC 9562 appendix A.6
+	tm, err = TimeFromUUID7("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	if err != nil || tm.UnixMilli() != 0x017f22e279b0 {
+		t.Fatalf("unexpected time of RFC example: %v, %v\n", tm, err)
+	}
+	var a, b = newUUID7At(time.UnixMilli(1000)).String(), newUUID7At(time.UnixMilli(1001)).String()
+	if a >= b {
+		t.Fatalf("UUIDv7 not time ordered: %s, %s\n", a, b)
+	}
+	if _, err = TimeFromUUID7(NewUUID4()); err != ErrInvalidUUID {
+		t.Fatalf("UUIDv4 should be rejected")
+	}
+}
+
+func Test_uuid5(t *testing.T) {
+	// Python: uuid.uuid5(uuid.NAMESPACE_DNS, "python.org")
+	var u = NewUUID5(NamespaceDNS, "python.org")
+	if u.String() != "886313e1-3b8a-5372-9b90-0c9aee199e5d" || u.Version() != 5 {
+		t.Fatalf("unexpected UUIDv5: %s\n", u)
+	}
+	if NewUUID5(NamespaceURL, "x").Base62() != NewUUID5(NamespaceURL, "x").Base62() || !ValidRID22(u.Base62()) {
+		t.Fatalf("UUIDv5 base62 form not stable")
+	}
+}
+
+func Test_uuid7Monotonic(t *testing.T) {
+	var g = NewUUID7Generator(true)
+	var now = time.UnixMilli(1700000000123)
+	var prev string
+	for i := 0; i < 1000; i++ {
+		var u = g.NewAt(now)
+		if u.Version() != 7 || !u.IsRFC4122() || u.String() <= prev {
+			t.Fatalf("UUIDv7 not increasing: %s <= %s\n", u, prev)
+		}
+		prev = u.String()
+	}
+	// overflow of all 74 random bits carries into the timestamp
+	g.last, _ = ParseUUID("018bcfe5-687b-7fff-bfff-ffffffffffff")
+	var u = g.NewAt(now)
+	if u.String() != "018bcfe5-687c-7000-8000-000000000000" {
+		t.Fatalf("unexpected carry %s\n", u)
+	}
+}
@@ -0,0 +1,207 @@
+// Package rid is version 2 of github.com/seckiss/rid. IDs come from a Generator, built with New and options
+// or with one of the format constructors RID16, RID20, Signed, Base58 and NanoID.
+// Invalid options are returned as errors by the constructors, and generating returns the failure of the
+// random source or key provider instead of exiting the process.
+//
+// The ID formats and their validation are those of version 1, so v1 and v2 IDs can be mixed freely.
+package rid
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+
+	v1 "github.com/seckiss/rid"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Generator and options
+///////////////////////////////////////////////////////////////////////////
+
+// Source is the ID generation interface for dependency injection, implemented by Generator.
+// NewIDn(n) returns an ID of n characters in total, see v1.Source.
+type Source interface {
+	NewID() (string, error)
+	NewIDn(n int) (string, error)
+}
+
+var _ Source = (*Generator)(nil)
+
+type Generator struct {
+	g *v1.Generator
+}
+
+type Option func(*options)
+
+// v1 options collected by the v2 options, and the first of their panics as an error
+type options struct {
+	opts []v1.Option
+	err  error
+}
+
+// option runs mk, which panics on invalid arguments like the v1 options do
+func option(mk func() v1.Option) Option {
+	return func(o *options) {
+		defer recoverErr(&o.err)
+		o.opts = append(o.opts, mk())
+	}
+}
+
+func recoverErr(err *error) {
+	if r := recover(); r != nil && *err == nil {
+		if e, ok := r.(error); ok {
+			*err = e
+		} else {
+			*err = fmt.Errorf("%v", r)
+		}
+	}
+}
+
+// Without options it generates RID20 from the internal generator
+func New(opts ...Option) (*Generator, error) {
+	return build(v1.New, opts)
+}
+
+// build applies opts and calls mk with the collected v1 options
+func build(mk func(...v1.Option) *v1.Generator, opts []Option) (g *Generator, err error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.err != nil {
+		return nil, o.err
+	}
+	defer recoverErr(&err)
+	return &Generator{g: mk(o.opts...)}, nil
+}
+
+// MustNew is like New but panics on invalid options
+func MustNew(opts ...Option) *Generator {
+	g, err := New(opts...)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+func WithLength(n int) Option {
+	return option(func() v1.Option { return v1.WithLength(n) })
+}
+
+// alphabet must consist of 2 to 256 distinct bytes
+func WithAlphabet(alphabet string) Option {
+	return option(func() v1.Option { return v1.WithAlphabet(alphabet) })
+}
+
+// r replaces the internal generator as the source of random bytes
+func WithRand(r io.Reader) Option {
+	return option(func() v1.Option { return v1.WithRand(r) })
+}
+
+// Reads directly from crypto/rand
+func WithCrypto() Option {
+	return WithRand(rand.Reader)
+}
+
+// IDs get the HMAC of the random part appended
+func WithSigner(secret string) Option {
+	return option(func() v1.Option { return v1.WithSigner(secret) })
+}
+
+// Signs with the current key of p, see v1.WithKeyProvider
+func WithKeyProvider(p v1.KeyProvider) Option {
+	return option(func() v1.Option { return v1.WithKeyProvider(p) })
+}
+
+// Signs with alg, IDs of alg and of the accepted algorithms are valid, see v1.WithMAC
+func WithMAC(alg *v1.MACAlgorithm, accepted ...*v1.MACAlgorithm) Option {
+	return option(func() v1.Option { return v1.WithMAC(alg, accepted...) })
+}
+
+// n bytes of MAC, 4 to 32
+func WithMACLength(n int) Option {
+	return option(func() v1.Option { return v1.WithMACLength(n) })
+}
+
+func WithBase62MAC() Option {
+	return option(v1.WithBase62MAC)
+}
+
+// IDs never begin with a digit, the alphabet must contain at least 2 non-digits
+func WithLetterFirst() Option {
+	return option(v1.WithLetterFirst)
+}
+
+func WithShard(code string, codes ...string) Option {
+	return option(func() v1.Option { return v1.WithShard(code, codes...) })
+}
+
+// IDs rejected by reject are regenerated
+func WithFilter(reject func(id string) bool) Option {
+	return option(func() v1.Option { return v1.WithFilter(reject) })
+}
+
+func WithProfanityFilter(extra ...string) Option {
+	return option(func() v1.Option { return v1.WithProfanityFilter(extra...) })
+}
+
+func (g *Generator) NewID() (string, error) {
+	return g.g.NewIDE()
+}
+
+func (g *Generator) NewIDn(n int) (string, error) {
+	return g.g.NewIDn(n)
+}
+
+// Valid checks the length, the alphabet and the signature if configured
+func (g *Generator) Valid(id string) bool {
+	return g.g.Valid(id)
+}
+
+// Entropy returns the bits of entropy in the random part of an ID
+func (g *Generator) Entropy() float64 {
+	return g.g.Entropy()
+}
+
+func (g *Generator) ShardOf(id string) (string, error) {
+	return g.g.ShardOf(id)
+}
+
+// V1 returns the underlying v1 generator, e.g. for v1.SetDefault
+func (g *Generator) V1() *v1.Generator {
+	return g.g
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Format constructors, opts are applied after the format's own options
+///////////////////////////////////////////////////////////////////////////
+
+// RID16: 16-chars of base62 gives about 95.3 bits of entropy
+func RID16(opts ...Option) (*Generator, error) {
+	return New(append([]Option{WithLength(16)}, opts...)...)
+}
+
+// RID20: 20-chars of base62 gives 119.1 bits of entropy
+func RID20(opts ...Option) (*Generator, error) {
+	return New(append([]Option{WithLength(20)}, opts...)...)
+}
+
+// RID20 with the HMAC of secret appended, same as v1.NewRID20Signed
+func Signed(secret string, opts ...Option) (*Generator, error) {
+	return RID20(append([]Option{WithSigner(secret)}, opts...)...)
+}
+
+// n chars of the Bitcoin base58 alphabet
+func Base58(n int, opts ...Option) (*Generator, error) {
+	return New(append([]Option{WithLength(n), WithAlphabet(v1.B58Alphabet)}, opts...)...)
+}
+
+// 21 chars of the nanoid alphabet, same as v1.NewNanoID
+func NanoID(opts ...Option) (*Generator, error) {
+	return New(append([]Option{WithLength(v1.NanoIDSize), WithAlphabet(v1.NanoIDAlphabet)}, opts...)...)
+}
+
+// Deterministic generates IDs that depend only on seed and opts, for tests, see v1.DeterministicGenerator
+func Deterministic(seed int64, opts ...Option) (*Generator, error) {
+	return build(func(o ...v1.Option) *v1.Generator { return v1.DeterministicGenerator(seed, o...) }, opts)
+}
@@ -0,0 +1,57 @@
+package rid
+
+import (
+	"testing"
+
+	v1 "github.com/seckiss/rid"
+)
+
+func Test_formats(t *testing.T) {
+	for _, c := range []struct {
+		mk    func(...Option) (*Generator, error)
+		valid func(string) bool
+	}{
+		{RID16, v1.ValidRID16},
+		{RID20, v1.ValidRID20},
+		{NanoID, v1.ValidNanoID},
+		{func(opts ...Option) (*Generator, error) { return Base58(20, opts...) }, v1.ValidBase58},
+	} {
+		g, err := c.mk(WithCrypto())
+		if err != nil {
+			t.Fatalf("unexpected error: %v\n", err)
+		}
+		if id, err := g.NewID(); err != nil || !c.valid(id) || !g.Valid(id) {
+			t.Fatalf("invalid ID %s, %v\n", id, err)
+		}
+	}
+	g, _ := Signed("secret")
+	if id, err := g.NewID(); err != nil || !v1.ValidRID20Signed(id, "secret") {
+		t.Fatalf("invalid signed ID %s, %v\n", id, err)
+	}
+}
+
+func Test_optionErrors(t *testing.T) {
+	for _, opts := range [][]Option{
+		{WithLength(0)},
+		{WithAlphabet("a")},
+		{WithMACLength(2)},
+		{WithLetterFirst(), WithAlphabet("0123456789")},
+	} {
+		if g, err := New(opts...); err == nil || g != nil {
+			t.Fatalf("invalid options accepted\n")
+		}
+	}
+	if _, err := MustNew().NewIDn(-1); err == nil {
+		t.Fatalf("negative length accepted\n")
+	}
+}
+
+func Test_deterministic(t *testing.T) {
+	a, _ := Deterministic(42)
+	b, _ := Deterministic(42)
+	x, _ := a.NewID()
+	y, _ := b.NewID()
+	if x != y || x != v1.DeterministicGenerator(42).NewID() {
+		t.Fatalf("deterministic IDs differ: %s %s\n", x, y)
+	}
+}
@@ -0,0 +1,58 @@
+package rid
+
+import (
+	"errors"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// RID value type, so that not any string can pass for an ID
+///////////////////////////////////////////////////////////////////////////
+
+type RID string
+
+var ErrInvalidRID = errors.New("rid: invalid RID")
+
+// ParseRID accepts any non-empty base62 string
+func ParseRID(s string) (RID, error) {
+	var r = RID(s)
+	if !r.Valid() {
+		return "", ErrInvalidRID
+	}
+	return r, nil
+}
+
+func (r RID) String() string {
+	return string(r)
+}
+
+func (r RID) Len() int {
+	return len(r)
+}
+
+// non-empty and base62 only
+func (r RID) Valid() bool {
+	return b62regexp.MatchString(string(r))
+}
+
+func (r RID) Bytes() []byte {
+	return []byte(r)
+}
+
+// the RID with HMAC appended, as in NewRID20Signed
+func (r RID) Signed(secret string) RID {
+	return r + RID(HMAC(string(r), secret))
+}
+
+func (r RID) MarshalText() ([]byte, error) {
+	return []byte(r), nil
+}
+
+// rejects values that are not valid RIDs
+func (r *RID) UnmarshalText(b []byte) error {
+	parsed, err := ParseRID(string(b))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
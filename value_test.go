@@ -0,0 +1,29 @@
+package rid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func Test_ridValue(t *testing.T) {
+	var r = RID(NewRID20())
+	if !r.Valid() || r.Len() != 20 || r.String() != string(r.Bytes()) {
+		t.Fatalf("unexpected RID value: %s\n", r)
+	}
+	if !ValidRID20Signed(r.Signed("secret").String(), "secret") {
+		t.Fatalf("signed RID not valid: %s\n", r.Signed("secret"))
+	}
+	if _, err := ParseRID("not-a-rid"); err != ErrInvalidRID {
+		t.Fatalf("expected ErrInvalidRID, got %v\n", err)
+	}
+}
+
+func Test_ridJSON(t *testing.T) {
+	var v struct{ ID RID }
+	if err := json.Unmarshal([]byte(`{"ID":"abc123"}`), &v); err != nil || v.ID != "abc123" {
+		t.Fatalf("unexpected unmarshal: %v, %v\n", v.ID, err)
+	}
+	if err := json.Unmarshal([]byte(`{"ID":"abc-123"}`), &v); err == nil {
+		t.Fatalf("invalid RID should not unmarshal")
+	}
+}
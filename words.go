@@ -0,0 +1,183 @@
+package rid
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// Word IDs, Diceware style, e.g. "cleft-rigid-oyster-snout"
+///////////////////////////////////////////////////////////////////////////
+
+type Wordlist []string
+
+var ErrShortWordlist = errors.New("rid: wordlist must have at least 2 words")
+
+// ParseWordlist reads one word per line. Diceware lists like the EFF large wordlist are accepted as is,
+// the leading dice numbers are dropped. Empty lines and # comments are skipped.
+func ParseWordlist(r io.Reader) (Wordlist, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var list Wordlist
+	var seen = make(map[string]bool)
+	for _, line := range parseWordlist(string(b)) {
+		var fields = strings.Fields(line)
+		var word = fields[len(fields)-1]
+		if seen[word] {
+			return nil, fmt.Errorf("rid: duplicate word %q in wordlist", word)
+		}
+		seen[word] = true
+		list = append(list, word)
+	}
+	if len(list) < 2 {
+		return nil, ErrShortWordlist
+	}
+	return list, nil
+}
+
+// NewWordID returns words drawn uniformly from the list with crypto randomness, joined by sep
+func (w Wordlist) NewWordID(words int, sep string) (string, error) {
+	if len(w) < 2 {
+		return "", ErrShortWordlist
+	}
+	var picked = make([]string, words)
+	for i := range picked {
+		x, err := uniformInt(cryptoReader(), len(w))
+		if err != nil {
+			return "", err
+		}
+		picked[i] = w[x]
+	}
+	return strings.Join(picked, sep), nil
+}
+
+// Entropy returns the bits of entropy of an ID of the given number of words.
+// For the 7776 words of the EFF large wordlist it is 12.92 bits per word, 6 words give 77.5 bits.
+func (w Wordlist) Entropy(words int) float64 {
+	return float64(words) * math.Log2(float64(len(w)))
+}
+
+//go:embed wordlists/eff_large_wordlist.txt
+var effLargeFile string
+
+// EFFLarge is the EFF large wordlist, the default of NewWordID
+var EFFLarge = mustParseWordlist(effLargeFile)
+
+func mustParseWordlist(s string) Wordlist {
+	w, err := ParseWordlist(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+// package wordlist, see SetWordlist
+var wordlist struct {
+	lk sync.RWMutex
+	w  Wordlist
+}
+
+// SetWordlist replaces EFFLarge as the list of NewWordID, e.g. with a list loaded with ParseWordlist.
+// SetWordlist(nil) restores EFFLarge.
+func SetWordlist(w Wordlist) error {
+	if w != nil && len(w) < 2 {
+		return ErrShortWordlist
+	}
+	wordlist.lk.Lock()
+	defer wordlist.lk.Unlock()
+	wordlist.w = w
+	return nil
+}
+
+// NewWordID draws from EFFLarge or the list set with SetWordlist
+func NewWordID(words int, sep string) (string, error) {
+	wordlist.lk.RLock()
+	var w = wordlist.w
+	wordlist.lk.RUnlock()
+	if w == nil {
+		w = EFFLarge
+	}
+	return w.NewWordID(words, sep)
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Friendly names for ephemeral environments, e.g. "quiet-violet-42"
+///////////////////////////////////////////////////////////////////////////
+
+var DefaultAdjectives = Wordlist{
+	"able", "amber", "ancient", "autumn", "billowing", "bitter", "black", "blue", "bold", "brave",
+	"brisk", "calm", "clever", "cold", "cool", "crimson", "curly", "damp", "dark", "dawn",
+	"delicate", "divine", "dry", "eager", "empty", "falling", "fancy", "fragrant", "frosty", "gentle",
+	"golden", "green", "hidden", "holy", "icy", "jolly", "late", "lingering", "little", "lively",
+	"long", "lucky", "misty", "morning", "muddy", "nameless", "noisy", "old", "patient", "polished",
+	"proud", "purple", "quiet", "rapid", "red", "restless", "rough", "shy", "silent", "small",
+	"snowy", "solitary", "sparkling", "spring", "steady", "still", "summer", "sunny", "swift", "tiny",
+	"twilight", "wandering", "weathered", "white", "wild", "winter", "wispy", "withered", "young", "zealous",
+}
+
+var DefaultNouns = Wordlist{
+	"bird", "breeze", "brook", "bush", "butterfly", "cake", "cell", "cherry", "cloud", "credit",
+	"darkness", "dawn", "dew", "disk", "dream", "dust", "feather", "field", "fire", "firefly",
+	"flower", "fog", "forest", "frog", "frost", "glade", "glitter", "grass", "hall", "hat",
+	"haze", "heart", "hill", "king", "lab", "lake", "leaf", "limit", "math", "meadow",
+	"mode", "moon", "morning", "mountain", "mouse", "mud", "night", "paper", "pine", "poetry",
+	"pond", "queen", "rain", "recipe", "resonance", "rice", "river", "salad", "scene", "sea",
+	"shadow", "shape", "silence", "sky", "smoke", "snow", "snowflake", "sound", "star", "sun",
+	"sunset", "surf", "term", "thunder", "tooth", "tree", "truth", "union", "unit", "violet",
+	"voice", "water", "waterfall", "wave", "wildflower", "wind", "wood",
+}
+
+// FriendlyNamer generates "adjective-noun" names, with "-number" appended when Suffix > 0
+type FriendlyNamer struct {
+	Adjectives Wordlist
+	Nouns      Wordlist
+	// the number is drawn from [0, Suffix), raise it to extend the name space
+	Suffix int
+}
+
+// NewFriendlyNamer checks the lists, a FriendlyNamer with an empty list panics in New
+func NewFriendlyNamer(adjectives, nouns Wordlist, suffix int) (FriendlyNamer, error) {
+	if len(adjectives) == 0 || len(nouns) == 0 {
+		return FriendlyNamer{}, errors.New("rid: FriendlyNamer needs adjectives and nouns")
+	}
+	return FriendlyNamer{Adjectives: adjectives, Nouns: nouns, Suffix: suffix}, nil
+}
+
+// DefaultAdjectives x DefaultNouns, 12.8 bits
+var defaultNamer = FriendlyNamer{Adjectives: DefaultAdjectives, Nouns: DefaultNouns}
+
+func NewFriendlyName() string {
+	return defaultNamer.New()
+}
+
+// with suffix drawn from [0, max)
+func NewFriendlyNameSuffix(max int) string {
+	var f = defaultNamer
+	f.Suffix = max
+	return f.New()
+}
+
+func (f FriendlyNamer) New() string {
+	var name = f.Adjectives[randomInt(len(f.Adjectives))] + "-" + f.Nouns[randomInt(len(f.Nouns))]
+	if f.Suffix > 0 {
+		name += "-" + strconv.Itoa(randomInt(f.Suffix))
+	}
+	return name
+}
+
+// bits of entropy of the names
+func (f FriendlyNamer) Entropy() float64 {
+	var e = math.Log2(float64(len(f.Adjectives))) + math.Log2(float64(len(f.Nouns)))
+	if f.Suffix > 0 {
+		e += math.Log2(float64(f.Suffix))
+	}
+	return e
+}
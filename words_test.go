@@ -0,0 +1,63 @@
+package rid
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_wordID(t *testing.T) {
+	if id, err := NewWordID(4, "-"); err != nil || len(strings.Split(id, "-")) < 4 {
+		t.Fatalf("unexpected word id from EFFLarge: %s, %v\n", id, err)
+	}
+	if EFFLarge[0] != "abacus" || EFFLarge[len(EFFLarge)-1] != "zoom" {
+		t.Fatalf("unexpected EFFLarge: %s..%s\n", EFFLarge[0], EFFLarge[len(EFFLarge)-1])
+	}
+	w, err := ParseWordlist(strings.NewReader("# dice\n11111\tabacus\n11112\tabdomen\n\n11113\tabdominal\n11114\tabide\n"))
+	if err != nil || len(w) != 4 || w[1] != "abdomen" {
+		t.Fatalf("unexpected wordlist: %v, %v\n", w, err)
+	}
+	if w.Entropy(3) != 6 {
+		t.Fatalf("unexpected entropy: %v\n", w.Entropy(3))
+	}
+	if err = SetWordlist(Wordlist{}); err != ErrShortWordlist {
+		t.Fatalf("empty wordlist set, %v\n", err)
+	}
+	if _, err = (Wordlist{}).NewWordID(3, "-"); err != ErrShortWordlist {
+		t.Fatalf("word ID from empty wordlist, %v\n", err)
+	}
+	defer SetWordlist(nil)
+	SetWordlist(w)
+	id, err := NewWordID(5, " ")
+	if err != nil || len(strings.Fields(id)) != 5 {
+		t.Fatalf("unexpected word id: %s, %v\n", id, err)
+	}
+	if _, err = ParseWordlist(strings.NewReader("a\nb\na\n")); err == nil {
+		t.Fatalf("duplicate words should be rejected")
+	}
+}
+
+func Test_friendlyName(t *testing.T) {
+	var parts = strings.Split(NewFriendlyName(), "-")
+	if len(parts) != 2 {
+		t.Fatalf("unexpected friendly name: %v\n", parts)
+	}
+	var f = FriendlyNamer{Adjectives: Wordlist{"quiet"}, Nouns: Wordlist{"violet"}, Suffix: 100}
+	if name := f.New(); !strings.HasPrefix(name, "quiet-violet-") || len(name) > len("quiet-violet-99") {
+		t.Fatalf("unexpected friendly name: %s\n", name)
+	}
+	if len(strings.Split(NewFriendlyNameSuffix(1000), "-")) != 3 {
+		t.Fatalf("expected numeric suffix")
+	}
+}
+
+func Test_friendlyNamerEmpty(t *testing.T) {
+	if _, err := NewFriendlyNamer(DefaultAdjectives, nil, 0); err == nil {
+		t.Fatalf("FriendlyNamer without nouns accepted\n")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("New with empty lists should panic\n")
+		}
+	}()
+	FriendlyNamer{}.New()
+}
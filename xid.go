@@ -0,0 +1,100 @@
+package rid
+
+import (
+	"crypto/md5"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+///////////////////////////////////////////////////////////////////////////
+// xid, MongoDB ObjectID layout in 20 chars of base32hex: 4-byte second timestamp, 3-byte machine ID,
+// 2-byte process ID and 3-byte counter. Globally unique and roughly sortable without coordination.
+///////////////////////////////////////////////////////////////////////////
+
+type XID [12]byte
+
+var ErrInvalidXID = errors.New("rid: invalid xid")
+
+// lowercase base32hex without padding, as github.com/rs/xid
+var xidEncoding = base32.NewEncoding("0123456789abcdefghijklmnopqrstuv").WithPadding(base32.NoPadding)
+
+var xidMachine = xidMachineID()
+
+var xidPid = uint16(os.Getpid())
+
+// starts at a random value, so that restarted processes don't repeat IDs of the same second
+var xidCounter = func() *atomic.Uint32 {
+	var c = new(atomic.Uint32)
+	c.Store(binary.BigEndian.Uint32(randomBytes(4)))
+	return c
+}()
+
+// first 3 bytes of the MD5 of the host name, like xid does when no platform ID is available
+func xidMachineID() [3]byte {
+	var id [3]byte
+	hostname, err := os.Hostname()
+	if err != nil {
+		copy(id[:], randomBytes(3))
+		return id
+	}
+	var sum = md5.Sum([]byte(hostname))
+	copy(id[:], sum[:3])
+	return id
+}
+
+func NewXID() string {
+	return NewXIDAt(time.Now()).String()
+}
+
+func NewXIDAt(t time.Time) XID {
+	var x XID
+	binary.BigEndian.PutUint32(x[:4], uint32(t.Unix()))
+	copy(x[4:7], xidMachine[:])
+	binary.BigEndian.PutUint16(x[7:9], xidPid)
+	var c = xidCounter.Add(1)
+	x[9], x[10], x[11] = byte(c>>16), byte(c>>8), byte(c)
+	return x
+}
+
+func (x XID) String() string {
+	return xidEncoding.EncodeToString(x[:])
+}
+
+func (x XID) Time() time.Time {
+	return time.Unix(int64(binary.BigEndian.Uint32(x[:4])), 0)
+}
+
+func (x XID) Machine() []byte {
+	return x[4:7]
+}
+
+func (x XID) Pid() uint16 {
+	return binary.BigEndian.Uint16(x[7:9])
+}
+
+func (x XID) Counter() uint32 {
+	return uint32(x[9])<<16 | uint32(x[10])<<8 | uint32(x[11])
+}
+
+func ParseXID(s string) (XID, error) {
+	var x XID
+	if len(s) != 20 {
+		return x, ErrInvalidXID
+	}
+	b, err := xidEncoding.DecodeString(s)
+	// the last character carries 4 unused bits, which must be zero
+	if err != nil || len(b) != 12 || xidEncoding.EncodeToString(b) != s {
+		return x, ErrInvalidXID
+	}
+	copy(x[:], b)
+	return x, nil
+}
+
+func ValidXID(s string) bool {
+	_, err := ParseXID(s)
+	return err == nil
+}
@@ -0,0 +1,23 @@
+package rid
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func Test_xid(t *testing.T) {
+	var now = time.Now()
+	var a, b = NewXIDAt(now), NewXIDAt(now)
+	if b.Counter() != (a.Counter()+1)&0xffffff || a.Pid() != uint16(os.Getpid()) || a.Time().Unix() != now.Unix() {
+		t.Fatalf("unexpected xid components: %v %v %v\n", a.Counter(), a.Pid(), a.Time())
+	}
+	x, err := ParseXID(a.String())
+	if err != nil || x != a || len(a.String()) != 20 {
+		t.Fatalf("xid round trip failed: %s, %v\n", a, err)
+	}
+	// example from the rs/xid README
+	if !ValidXID("9m4e2mr0ui3e8a215n4g") || ValidXID("9m4e2mr0ui3e8a215n4w") || ValidXID("9m4e2mr0ui3e8a215n4") {
+		t.Fatalf("xid validation mismatch")
+	}
+}